@@ -0,0 +1,73 @@
+package appsec
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// Network is the Akamai network a configuration version is activated on.
+type Network string
+
+const (
+	// Staging is the Akamai staging network.
+	Staging Network = "STAGING"
+	// Production is the Akamai production network.
+	Production Network = "PRODUCTION"
+)
+
+// ActivationRequest is the body of an activation request.
+type ActivationRequest struct {
+	// Action is "ACTIVATE" or "DEACTIVATE"; Activate defaults it to "ACTIVATE".
+	Action             string             `json:"action"`
+	Network            Network            `json:"network"`
+	Note               string             `json:"note"`
+	NotificationEmails []string           `json:"notificationEmails"`
+	ActivationConfigs  []ActivationConfig `json:"activationConfigs"`
+}
+
+// ActivationConfig identifies a configuration version to activate.
+type ActivationConfig struct {
+	ConfigID      int `json:"configId"`
+	ConfigVersion int `json:"configVersion"`
+}
+
+// Activation is the status of an activation request.
+type Activation struct {
+	ActivationID      int                `json:"activationId"`
+	Action            string             `json:"action"`
+	Status            string             `json:"status"`
+	Network           Network            `json:"network"`
+	CreatedBy         string             `json:"createdBy"`
+	CreateDate        string             `json:"createDate"`
+	ActivationConfigs []ActivationConfig `json:"activationConfigs"`
+}
+
+// Terminal reports whether the activation has reached a final status.
+func (a *Activation) Terminal() bool {
+	switch a.Status {
+	case "ACTIVATED", "DEACTIVATED", "FAILED", "ABORTED":
+		return true
+	}
+	return false
+}
+
+// Activate requests the activation of configuration versions.
+func (c *Client) Activate(ctx context.Context, req ActivationRequest) (*Activation, error) {
+	if req.Action == "" {
+		req.Action = "ACTIVATE"
+	}
+	if req.NotificationEmails == nil {
+		req.NotificationEmails = []string{}
+	}
+
+	activation, _, err := edgegrid.SendJSON[ActivationRequest, *Activation](ctx, c.client, "POST", "/appsec/v1/activations", req)
+	return activation, err
+}
+
+// Activation returns the status of the activation activationID.
+func (c *Client) Activation(ctx context.Context, activationID int) (*Activation, error) {
+	activation, _, err := edgegrid.GetJSON[*Activation](ctx, c.client, fmt.Sprintf("/appsec/v1/activations/%d", activationID), nil)
+	return activation, err
+}
@@ -0,0 +1,145 @@
+// Package appsec provides a client for the Akamai Application Security API: security
+// configurations and their versions, security policies, rate policies, custom rules, match
+// targets and activations.
+//
+// Activated configuration versions are locked. Modify finds or clones an editable version
+// before applying changes, so automated changes never touch a version that is or was active.
+package appsec
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// Configuration is a security configuration.
+type Configuration struct {
+	ID                int      `json:"id"`
+	Name              string   `json:"name"`
+	Description       string   `json:"description,omitempty"`
+	FileType          string   `json:"fileType,omitempty"`
+	LatestVersion     int      `json:"latestVersion"`
+	StagingVersion    int      `json:"stagingVersion,omitempty"`
+	ProductionVersion int      `json:"productionVersion,omitempty"`
+	ProductionHosts   []string `json:"productionHostnames,omitempty"`
+}
+
+// Version is a version of a security configuration.
+type Version struct {
+	ConfigID     int           `json:"configId"`
+	ConfigName   string        `json:"configName,omitempty"`
+	Version      int           `json:"version"`
+	VersionNotes string        `json:"versionNotes,omitempty"`
+	CreateDate   string        `json:"createDate,omitempty"`
+	CreatedBy    string        `json:"createdBy,omitempty"`
+	BasedOn      int           `json:"basedOn,omitempty"`
+	Production   VersionStatus `json:"production"`
+	Staging      VersionStatus `json:"staging"`
+}
+
+// VersionStatus is the activation status of a version on a network.
+type VersionStatus struct {
+	Status string `json:"status"`
+	Time   string `json:"time,omitempty"`
+}
+
+// Locked reports whether the version has been activated on either network, which makes it read-only.
+func (v *Version) Locked() bool {
+	return !inactive(v.Staging.Status) || !inactive(v.Production.Status)
+}
+
+func inactive(status string) bool {
+	return status == "" || status == "Inactive"
+}
+
+// Client is an Application Security API client.
+type Client struct {
+	client *edgegrid.Client
+}
+
+// New creates an Application Security client sending requests with client.
+func New(client *edgegrid.Client) *Client {
+	return &Client{client: client}
+}
+
+// Configurations lists the security configurations of the account.
+func (c *Client) Configurations(ctx context.Context) ([]Configuration, error) {
+	res, _, err := edgegrid.GetJSON[struct {
+		Configurations []Configuration `json:"configurations"`
+	}](ctx, c.client, "/appsec/v1/configs", nil)
+	return res.Configurations, err
+}
+
+// Configuration returns the security configuration configID.
+func (c *Client) Configuration(ctx context.Context, configID int) (*Configuration, error) {
+	config, _, err := edgegrid.GetJSON[*Configuration](ctx, c.client, configPath(configID), nil)
+	return config, err
+}
+
+// Versions lists the versions of the security configuration configID.
+func (c *Client) Versions(ctx context.Context, configID int) ([]Version, error) {
+	p := edgegrid.NewPaginator(c.client, configPath(configID)+"/versions", edgegrid.NewPageNumber(100), edgegrid.ItemsAt[Version]("versionList"))
+	return p.Collect(ctx)
+}
+
+// Version returns version of the security configuration configID.
+func (c *Client) Version(ctx context.Context, configID, version int) (*Version, error) {
+	v, _, err := edgegrid.GetJSON[*Version](ctx, c.client, versionPath(configID, version), nil)
+	return v, err
+}
+
+// CloneVersion creates a version of the security configuration configID from fromVersion.
+func (c *Client) CloneVersion(ctx context.Context, configID, fromVersion int) (*Version, error) {
+	v, _, err := edgegrid.SendJSON[map[string]interface{}, *Version](ctx, c.client, "POST", configPath(configID)+"/versions", map[string]interface{}{
+		"createFromVersion": fromVersion,
+		"ruleUpdate":        false,
+	})
+	return v, err
+}
+
+// EditableVersion returns the latest version of the security configuration configID if it has
+// never been activated, or else a new version cloned from it.
+func (c *Client) EditableVersion(ctx context.Context, configID int) (*Version, error) {
+	config, err := c.Configuration(ctx, configID)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := c.Version(ctx, configID, config.LatestVersion)
+	if err != nil {
+		return nil, err
+	}
+	if !latest.Locked() {
+		return latest, nil
+	}
+
+	return c.CloneVersion(ctx, configID, latest.Version)
+}
+
+// Modify calls fn with an editable version of the security configuration configID, see
+// EditableVersion, and returns that version. Changes made by fn should target the version it
+// is given.
+func (c *Client) Modify(ctx context.Context, configID int, fn func(version int) error) (int, error) {
+	v, err := c.EditableVersion(ctx, configID)
+	if err != nil {
+		return 0, err
+	}
+	if err := fn(v.Version); err != nil {
+		return v.Version, fmt.Errorf("Unable to modify version %d of configuration %d: %w", v.Version, configID, err)
+	}
+	return v.Version, nil
+}
+
+func configPath(configID int) string {
+	return fmt.Sprintf("/appsec/v1/configs/%d", configID)
+}
+
+func versionPath(configID, version int) string {
+	return fmt.Sprintf("%s/versions/%d", configPath(configID), version)
+}
+
+func policyPath(configID, version int, policyID string) string {
+	return versionPath(configID, version) + "/security-policies/" + url.PathEscape(policyID)
+}
@@ -0,0 +1,196 @@
+package appsec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/edgegridtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newVersionServer serves configuration 4711 whose latest version 3 has the given staging status.
+func newVersionServer(t *testing.T, stagingStatus string) *edgegridtest.Server {
+	s := edgegridtest.NewServer()
+	s.HandleJSON("GET /appsec/v1/configs/4711", http.StatusOK, Configuration{ID: 4711, Name: "WAF", LatestVersion: 3, StagingVersion: 3})
+	s.HandleJSON("GET /appsec/v1/configs/4711/versions/3", http.StatusOK, Version{ConfigID: 4711, Version: 3, Staging: VersionStatus{Status: stagingStatus}, Production: VersionStatus{Status: "Inactive"}})
+	s.HandleFunc("POST /appsec/v1/configs/4711/versions", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			CreateFromVersion int `json:"createFromVersion"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(Version{ConfigID: 4711, Version: 4, BasedOn: body.CreateFromVersion})
+	})
+	return s
+}
+
+func TestModifyClonesLockedVersion(t *testing.T) {
+	s := newVersionServer(t, "Active")
+	defer s.Close()
+
+	var modified int
+	version, err := New(s.Client()).Modify(context.Background(), 4711, func(version int) error {
+		modified = version
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, version)
+	assert.Equal(t, 4, modified)
+	assert.JSONEq(t, `{"createFromVersion":3,"ruleUpdate":false}`, string(s.Requests()[2].Body))
+}
+
+func TestModifyReusesEditableVersion(t *testing.T) {
+	s := newVersionServer(t, "Inactive")
+	defer s.Close()
+
+	version, err := New(s.Client()).Modify(context.Background(), 4711, func(version int) error {
+		return fmt.Errorf("boom")
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "version 3 of configuration 4711")
+	assert.Equal(t, 3, version)
+	assert.Len(t, s.Requests(), 2)
+}
+
+func TestVersionLocked(t *testing.T) {
+	assert.False(t, (&Version{}).Locked())
+	assert.False(t, (&Version{Staging: VersionStatus{Status: "Inactive"}}).Locked())
+	assert.True(t, (&Version{Production: VersionStatus{Status: "Deactivated"}}).Locked())
+	assert.True(t, (&Version{Staging: VersionStatus{Status: "Pending"}}).Locked())
+}
+
+const ratePolicy = `{
+	"id": 134644,
+	"name": "Origin Error",
+	"description": "An excessive error rate from the origin",
+	"matchType": "path",
+	"type": "WAF",
+	"clientIdentifier": "ip",
+	"requestType": "ForwardResponse",
+	"averageThreshold": 5,
+	"burstThreshold": 8,
+	"sameActionOnIpv6": true,
+	"useXForwardForHeaders": false,
+	"pathMatchType": "Custom",
+	"path": {"positiveMatch": true, "values": ["/login", "/search"]},
+	"additionalMatchOptions": [{"positiveMatch": true, "type": "ResponseStatusCondition", "values": ["400", "401", "403"]}],
+	"fileExtensions": {"positiveMatch": false, "values": ["jpg", "png"]}
+}`
+
+func TestRatePolicyUpdateKeepsUnmodeledFields(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleRaw("GET /appsec/v1/configs/4711/versions/4/rate-policies", http.StatusOK, "application/json", []byte(`{"ratePolicies":[`+ratePolicy+`]}`))
+	s.HandleFunc("PUT /appsec/v1/configs/4711/versions/4/rate-policies/134644", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	})
+
+	c := New(s.Client())
+	ctx := context.Background()
+
+	policies, err := c.RatePolicies(ctx, 4711, 4)
+	assert.NoError(t, err)
+	if !assert.Len(t, policies, 1) {
+		return
+	}
+	policy := policies[0]
+	assert.Contains(t, policy.Extra(), "additionalMatchOptions")
+
+	policy.AverageThreshold = 10
+	updated, err := c.UpdateRatePolicy(ctx, 4711, 4, &policy)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, updated.AverageThreshold)
+
+	expected := map[string]interface{}{}
+	json.Unmarshal([]byte(ratePolicy), &expected)
+	expected["averageThreshold"] = 10
+	want, _ := json.Marshal(expected)
+	assert.JSONEq(t, string(want), string(s.Requests()[1].Body))
+}
+
+func TestPoliciesAndMatchTargets(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleRaw("GET /appsec/v1/configs/4711/versions/4/security-policies", http.StatusOK, "application/json", []byte(`{"configId":4711,"version":4,"policies":[{"policyId":"abc1_12345","policyName":"Default","policySecurityControls":{"applyApplicationLayerControls":true,"applyRateControls":true}}]}`))
+	s.HandleFunc("POST /appsec/v1/configs/4711/versions/4/security-policies", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"policyId":"api1_67890","policyName":"API"}`))
+	})
+	s.HandleRaw("GET /appsec/v1/configs/4711/versions/4/match-targets", http.StatusOK, "application/json", []byte(`{"matchTargets":{"websiteTargets":[{"targetId":1,"type":"website","hostnames":["www.example.com"],"securityPolicy":{"policyId":"abc1_12345"}}],"apiTargets":[{"targetId":2,"type":"api","apis":[{"id":1234}],"securityPolicy":{"policyId":"api1_67890"}}]}}`))
+	s.HandleFunc("POST /appsec/v1/configs/4711/versions/4/match-targets", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	})
+
+	c := New(s.Client())
+	ctx := context.Background()
+
+	policies, err := c.Policies(ctx, 4711, 4)
+	assert.NoError(t, err)
+	assert.True(t, policies[0].PolicySecurityControls.ApplyRateControls)
+
+	policy, err := c.CreatePolicy(ctx, 4711, 4, CreatePolicyRequest{PolicyName: "API", PolicyPrefix: "api1", DefaultSettings: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "api1_67890", policy.PolicyID)
+
+	targets, err := c.MatchTargets(ctx, 4711, 4)
+	assert.NoError(t, err)
+	if assert.Len(t, targets, 2) {
+		assert.Equal(t, "api", targets[1].Type)
+		assert.Contains(t, targets[1].Extra(), "apis")
+	}
+
+	_, err = c.CreateMatchTarget(ctx, 4711, 4, &MatchTarget{Type: "website", Hostnames: []string{"shop.example.com"}, SecurityPolicy: TargetPolicy{PolicyID: "abc1_12345"}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"website","hostnames":["shop.example.com"],"securityPolicy":{"policyId":"abc1_12345"}}`, string(s.Requests()[3].Body))
+}
+
+func TestCustomRules(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleRaw("GET /appsec/v1/configs/4711/custom-rules/60039625", http.StatusOK, "application/json", []byte(`{"id":60039625,"name":"Block bad bots","version":2,"conditions":[{"type":"requestHeaderMatch","positiveMatch":true,"header":"User-Agent","value":["badbot"],"valueWildcard":true}],"ruleActivated":true}`))
+	s.HandleFunc("PUT /appsec/v1/configs/4711/custom-rules/60039625", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	})
+
+	c := New(s.Client())
+	ctx := context.Background()
+
+	rule, err := c.CustomRule(ctx, 4711, 60039625)
+	assert.NoError(t, err)
+	rule.Conditions[0].Value = append(rule.Conditions[0].Value, "worsebot")
+
+	_, err = c.UpdateCustomRule(ctx, 4711, rule)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":60039625,"name":"Block bad bots","version":2,"conditions":[{"type":"requestHeaderMatch","positiveMatch":true,"header":"User-Agent","value":["badbot","worsebot"],"valueWildcard":true}],"ruleActivated":true}`, string(s.Requests()[1].Body))
+}
+
+func TestActivate(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleFunc("POST /appsec/v1/activations", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"activationId":3456,"action":"ACTIVATE","status":"RECEIVED","network":"STAGING","activationConfigs":[{"configId":4711,"configVersion":4}]}`))
+	})
+	s.HandleJSON("GET /appsec/v1/activations/3456", http.StatusOK, Activation{ActivationID: 3456, Status: "ACTIVATED"})
+
+	c := New(s.Client())
+	ctx := context.Background()
+
+	activation, err := c.Activate(ctx, ActivationRequest{Network: Staging, Note: "tuning", ActivationConfigs: []ActivationConfig{{ConfigID: 4711, ConfigVersion: 4}}})
+	assert.NoError(t, err)
+	assert.Equal(t, 3456, activation.ActivationID)
+	assert.False(t, activation.Terminal())
+	assert.JSONEq(t, `{"action":"ACTIVATE","network":"STAGING","note":"tuning","notificationEmails":[],"activationConfigs":[{"configId":4711,"configVersion":4}]}`, string(s.Requests()[0].Body))
+
+	activation, err = c.Activation(ctx, 3456)
+	assert.NoError(t, err)
+	assert.True(t, activation.Terminal())
+}
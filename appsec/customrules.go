@@ -0,0 +1,99 @@
+package appsec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/internal/jsonobject"
+)
+
+// CustomRule is a custom rule of a security configuration. Custom rules belong to the
+// configuration, not to a version. See Extra for the fields not modeled here.
+type CustomRule struct {
+	ID          int         `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Version     int         `json:"version"`
+	Tag         []string    `json:"tag"`
+	Conditions  []Condition `json:"conditions"`
+	Structured  bool        `json:"structured"`
+
+	meta jsonobject.Meta
+}
+
+// Condition is a match condition of a custom rule.
+type Condition struct {
+	Type          string   `json:"type"`
+	PositiveMatch bool     `json:"positiveMatch"`
+	Value         []string `json:"value"`
+
+	meta jsonobject.Meta
+}
+
+type (
+	customRuleAlias CustomRule
+	conditionAlias  Condition
+)
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *CustomRule) UnmarshalJSON(data []byte) error {
+	return r.meta.Unmarshal(data, (*customRuleAlias)(r))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r CustomRule) MarshalJSON() ([]byte, error) {
+	return r.meta.Marshal((*customRuleAlias)(&r), "name", "conditions")
+}
+
+// Extra returns the fields of the custom rule not modeled by CustomRule.
+func (r *CustomRule) Extra() map[string]json.RawMessage {
+	return r.meta.Extra()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Condition) UnmarshalJSON(data []byte) error {
+	return c.meta.Unmarshal(data, (*conditionAlias)(c))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c Condition) MarshalJSON() ([]byte, error) {
+	return c.meta.Marshal((*conditionAlias)(&c), "type", "positiveMatch")
+}
+
+// CustomRules lists the custom rules of the configuration configID.
+func (c *Client) CustomRules(ctx context.Context, configID int) ([]CustomRule, error) {
+	res, _, err := edgegrid.GetJSON[struct {
+		CustomRules []CustomRule `json:"customRules"`
+	}](ctx, c.client, configPath(configID)+"/custom-rules", nil)
+	return res.CustomRules, err
+}
+
+// CustomRule returns the custom rule ruleID.
+func (c *Client) CustomRule(ctx context.Context, configID, ruleID int) (*CustomRule, error) {
+	rule, _, err := edgegrid.GetJSON[*CustomRule](ctx, c.client, customRulePath(configID, ruleID), nil)
+	return rule, err
+}
+
+// CreateCustomRule creates a custom rule.
+func (c *Client) CreateCustomRule(ctx context.Context, configID int, rule *CustomRule) (*CustomRule, error) {
+	created, _, err := edgegrid.SendJSON[*CustomRule, *CustomRule](ctx, c.client, "POST", configPath(configID)+"/custom-rules", rule)
+	return created, err
+}
+
+// UpdateCustomRule replaces the custom rule rule.ID with rule.
+func (c *Client) UpdateCustomRule(ctx context.Context, configID int, rule *CustomRule) (*CustomRule, error) {
+	updated, _, err := edgegrid.SendJSON[*CustomRule, *CustomRule](ctx, c.client, "PUT", customRulePath(configID, rule.ID), rule)
+	return updated, err
+}
+
+// RemoveCustomRule deletes the custom rule ruleID. Rules used by a policy cannot be removed.
+func (c *Client) RemoveCustomRule(ctx context.Context, configID, ruleID int) error {
+	_, _, err := edgegrid.SendJSON[*struct{}, struct{}](ctx, c.client, "DELETE", customRulePath(configID, ruleID), nil)
+	return err
+}
+
+func customRulePath(configID, ruleID int) string {
+	return fmt.Sprintf("%s/custom-rules/%d", configPath(configID), ruleID)
+}
@@ -0,0 +1,219 @@
+package appsec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/internal/jsonobject"
+)
+
+// SecurityPolicy is a security policy of a configuration version.
+type SecurityPolicy struct {
+	PolicyID                string           `json:"policyId"`
+	PolicyName              string           `json:"policyName"`
+	HasRatePolicyWithAPIKey bool             `json:"hasRatePolicyWithApiKey,omitempty"`
+	PolicySecurityControls  SecurityControls `json:"policySecurityControls"`
+}
+
+// SecurityControls lists the protections enabled in a security policy.
+type SecurityControls struct {
+	ApplyAPIConstraints           bool `json:"applyApiConstraints"`
+	ApplyApplicationLayerControls bool `json:"applyApplicationLayerControls"`
+	ApplyBotmanControls           bool `json:"applyBotmanControls"`
+	ApplyNetworkLayerControls     bool `json:"applyNetworkLayerControls"`
+	ApplyRateControls             bool `json:"applyRateControls"`
+	ApplyReputationControls       bool `json:"applyReputationControls"`
+	ApplySlowPostControls         bool `json:"applySlowPostControls"`
+}
+
+// CreatePolicyRequest is the body of a security policy creation request.
+type CreatePolicyRequest struct {
+	PolicyName      string `json:"policyName"`
+	PolicyPrefix    string `json:"policyPrefix"`
+	DefaultSettings bool   `json:"defaultSettings"`
+	CreateFromID    string `json:"createFromSecurityPolicy,omitempty"`
+}
+
+// RatePolicy is a rate policy of a configuration version. See Extra for the fields not modeled
+// here.
+type RatePolicy struct {
+	ID                    int    `json:"id"`
+	Name                  string `json:"name"`
+	Description           string `json:"description"`
+	MatchType             string `json:"matchType"`
+	Type                  string `json:"type"`
+	ClientIdentifier      string `json:"clientIdentifier"`
+	RequestType           string `json:"requestType"`
+	AverageThreshold      int    `json:"averageThreshold"`
+	BurstThreshold        int    `json:"burstThreshold"`
+	SameActionOnIPv6      bool   `json:"sameActionOnIpv6"`
+	UseXForwardForHeaders bool   `json:"useXForwardForHeaders"`
+
+	meta jsonobject.Meta
+}
+
+type ratePolicyAlias RatePolicy
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *RatePolicy) UnmarshalJSON(data []byte) error {
+	return p.meta.Unmarshal(data, (*ratePolicyAlias)(p))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p RatePolicy) MarshalJSON() ([]byte, error) {
+	return p.meta.Marshal((*ratePolicyAlias)(&p), "name", "matchType", "type", "averageThreshold", "burstThreshold")
+}
+
+// Extra returns the fields of the rate policy not modeled by RatePolicy.
+func (p *RatePolicy) Extra() map[string]json.RawMessage {
+	return p.meta.Extra()
+}
+
+// MatchTarget selects the requests a security policy applies to.
+type MatchTarget struct {
+	TargetID                     int          `json:"targetId"`
+	Type                         string       `json:"type"`
+	Sequence                     int          `json:"sequence"`
+	ConfigID                     int          `json:"configId"`
+	ConfigVersion                int          `json:"configVersion"`
+	Hostnames                    []string     `json:"hostnames"`
+	FilePaths                    []string     `json:"filePaths"`
+	FileExtensions               []string     `json:"fileExtensions"`
+	IsNegativePathMatch          bool         `json:"isNegativePathMatch"`
+	IsNegativeFileExtensionMatch bool         `json:"isNegativeFileExtensionMatch"`
+	DefaultFile                  string       `json:"defaultFile"`
+	SecurityPolicy               TargetPolicy `json:"securityPolicy"`
+
+	meta jsonobject.Meta
+}
+
+// TargetPolicy identifies the security policy of a match target.
+type TargetPolicy struct {
+	PolicyID string `json:"policyId"`
+}
+
+type matchTargetAlias MatchTarget
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *MatchTarget) UnmarshalJSON(data []byte) error {
+	return t.meta.Unmarshal(data, (*matchTargetAlias)(t))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t MatchTarget) MarshalJSON() ([]byte, error) {
+	return t.meta.Marshal((*matchTargetAlias)(&t), "type", "securityPolicy")
+}
+
+// Extra returns the fields of the match target not modeled by MatchTarget.
+func (t *MatchTarget) Extra() map[string]json.RawMessage {
+	return t.meta.Extra()
+}
+
+// Policies lists the security policies of version of the configuration configID.
+func (c *Client) Policies(ctx context.Context, configID, version int) ([]SecurityPolicy, error) {
+	res, _, err := edgegrid.GetJSON[struct {
+		Policies []SecurityPolicy `json:"policies"`
+	}](ctx, c.client, versionPath(configID, version)+"/security-policies", nil)
+	return res.Policies, err
+}
+
+// Policy returns the security policy policyID.
+func (c *Client) Policy(ctx context.Context, configID, version int, policyID string) (*SecurityPolicy, error) {
+	policy, _, err := edgegrid.GetJSON[*SecurityPolicy](ctx, c.client, policyPath(configID, version, policyID), nil)
+	return policy, err
+}
+
+// CreatePolicy creates a security policy.
+func (c *Client) CreatePolicy(ctx context.Context, configID, version int, req CreatePolicyRequest) (*SecurityPolicy, error) {
+	policy, _, err := edgegrid.SendJSON[CreatePolicyRequest, *SecurityPolicy](ctx, c.client, "POST", versionPath(configID, version)+"/security-policies", req)
+	return policy, err
+}
+
+// RenamePolicy changes the name of the security policy policyID.
+func (c *Client) RenamePolicy(ctx context.Context, configID, version int, policyID, name string) (*SecurityPolicy, error) {
+	policy, _, err := edgegrid.SendJSON[map[string]string, *SecurityPolicy](ctx, c.client, "PUT", policyPath(configID, version, policyID), map[string]string{"policyName": name})
+	return policy, err
+}
+
+// RemovePolicy deletes the security policy policyID.
+func (c *Client) RemovePolicy(ctx context.Context, configID, version int, policyID string) error {
+	_, _, err := edgegrid.SendJSON[*struct{}, struct{}](ctx, c.client, "DELETE", policyPath(configID, version, policyID), nil)
+	return err
+}
+
+// RatePolicies lists the rate policies of version of the configuration configID.
+func (c *Client) RatePolicies(ctx context.Context, configID, version int) ([]RatePolicy, error) {
+	res, _, err := edgegrid.GetJSON[struct {
+		RatePolicies []RatePolicy `json:"ratePolicies"`
+	}](ctx, c.client, versionPath(configID, version)+"/rate-policies", nil)
+	return res.RatePolicies, err
+}
+
+// RatePolicy returns the rate policy ratePolicyID.
+func (c *Client) RatePolicy(ctx context.Context, configID, version, ratePolicyID int) (*RatePolicy, error) {
+	policy, _, err := edgegrid.GetJSON[*RatePolicy](ctx, c.client, ratePolicyPath(configID, version, ratePolicyID), nil)
+	return policy, err
+}
+
+// CreateRatePolicy creates a rate policy.
+func (c *Client) CreateRatePolicy(ctx context.Context, configID, version int, policy *RatePolicy) (*RatePolicy, error) {
+	created, _, err := edgegrid.SendJSON[*RatePolicy, *RatePolicy](ctx, c.client, "POST", versionPath(configID, version)+"/rate-policies", policy)
+	return created, err
+}
+
+// UpdateRatePolicy replaces the rate policy policy.ID with policy.
+func (c *Client) UpdateRatePolicy(ctx context.Context, configID, version int, policy *RatePolicy) (*RatePolicy, error) {
+	updated, _, err := edgegrid.SendJSON[*RatePolicy, *RatePolicy](ctx, c.client, "PUT", ratePolicyPath(configID, version, policy.ID), policy)
+	return updated, err
+}
+
+// RemoveRatePolicy deletes the rate policy ratePolicyID.
+func (c *Client) RemoveRatePolicy(ctx context.Context, configID, version, ratePolicyID int) error {
+	_, _, err := edgegrid.SendJSON[*struct{}, struct{}](ctx, c.client, "DELETE", ratePolicyPath(configID, version, ratePolicyID), nil)
+	return err
+}
+
+// MatchTargets lists the website and API match targets of version of the configuration configID.
+func (c *Client) MatchTargets(ctx context.Context, configID, version int) ([]MatchTarget, error) {
+	res, _, err := edgegrid.GetJSON[struct {
+		MatchTargets struct {
+			WebsiteTargets []MatchTarget `json:"websiteTargets"`
+			APITargets     []MatchTarget `json:"apiTargets"`
+		} `json:"matchTargets"`
+	}](ctx, c.client, versionPath(configID, version)+"/match-targets", nil)
+	return append(res.MatchTargets.WebsiteTargets, res.MatchTargets.APITargets...), err
+}
+
+// MatchTarget returns the match target targetID.
+func (c *Client) MatchTarget(ctx context.Context, configID, version, targetID int) (*MatchTarget, error) {
+	target, _, err := edgegrid.GetJSON[*MatchTarget](ctx, c.client, matchTargetPath(configID, version, targetID), nil)
+	return target, err
+}
+
+// CreateMatchTarget creates a match target.
+func (c *Client) CreateMatchTarget(ctx context.Context, configID, version int, target *MatchTarget) (*MatchTarget, error) {
+	created, _, err := edgegrid.SendJSON[*MatchTarget, *MatchTarget](ctx, c.client, "POST", versionPath(configID, version)+"/match-targets", target)
+	return created, err
+}
+
+// UpdateMatchTarget replaces the match target target.TargetID with target.
+func (c *Client) UpdateMatchTarget(ctx context.Context, configID, version int, target *MatchTarget) (*MatchTarget, error) {
+	updated, _, err := edgegrid.SendJSON[*MatchTarget, *MatchTarget](ctx, c.client, "PUT", matchTargetPath(configID, version, target.TargetID), target)
+	return updated, err
+}
+
+// RemoveMatchTarget deletes the match target targetID.
+func (c *Client) RemoveMatchTarget(ctx context.Context, configID, version, targetID int) error {
+	_, _, err := edgegrid.SendJSON[*struct{}, struct{}](ctx, c.client, "DELETE", matchTargetPath(configID, version, targetID), nil)
+	return err
+}
+
+func ratePolicyPath(configID, version, ratePolicyID int) string {
+	return fmt.Sprintf("%s/rate-policies/%d", versionPath(configID, version), ratePolicyID)
+}
+
+func matchTargetPath(configID, version, targetID int) string {
+	return fmt.Sprintf("%s/match-targets/%d", versionPath(configID, version), targetID)
+}
@@ -0,0 +1,180 @@
+// Package ccu provides a client for the Akamai Fast Purge (CCU v3) API.
+//
+// Content can be invalidated or deleted by URL, CP code or cache tag on the
+// staging or production network. Large purges are split automatically into
+// requests that fit the API's payload size limit.
+package ccu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// MaxPayloadSize is the maximum size in bytes of a single purge request body accepted by the API.
+const MaxPayloadSize = 50000
+
+// Network is the Akamai network content is purged from.
+type Network string
+
+// Action is the kind of purge to perform.
+type Action string
+
+// ObjectType is the kind of objects being purged.
+type ObjectType string
+
+const (
+	// Staging is the Akamai staging network.
+	Staging Network = "staging"
+	// Production is the Akamai production network.
+	Production Network = "production"
+
+	// Invalidate marks content as stale, so it is revalidated with the origin on the next request.
+	Invalidate Action = "invalidate"
+	// Delete removes content from cache, so it is fetched from the origin on the next request.
+	Delete Action = "delete"
+
+	// URL purges by URL or ARL.
+	URL ObjectType = "url"
+	// CPCode purges all content of a CP code.
+	CPCode ObjectType = "cpcode"
+	// Tag purges all content with a cache tag.
+	Tag ObjectType = "tag"
+)
+
+// PurgeResponse is the response to a single purge request.
+type PurgeResponse struct {
+	HTTPStatus       int    `json:"httpStatus"`
+	EstimatedSeconds int    `json:"estimatedSeconds"`
+	PurgeID          string `json:"purgeId"`
+	SupportID        string `json:"supportId"`
+	Detail           string `json:"detail"`
+}
+
+type purgeRequest struct {
+	Objects []json.RawMessage `json:"objects"`
+}
+
+// Client is a Fast Purge API client.
+type Client struct {
+	client *edgegrid.Client
+}
+
+// New creates a Fast Purge client sending requests with client.
+func New(client *edgegrid.Client) *Client {
+	return &Client{client: client}
+}
+
+// InvalidateURLs invalidates urls on network.
+func (c *Client) InvalidateURLs(ctx context.Context, network Network, urls ...string) ([]PurgeResponse, error) {
+	return c.Purge(ctx, Invalidate, URL, network, stringObjects(urls))
+}
+
+// DeleteURLs deletes urls from cache on network.
+func (c *Client) DeleteURLs(ctx context.Context, network Network, urls ...string) ([]PurgeResponse, error) {
+	return c.Purge(ctx, Delete, URL, network, stringObjects(urls))
+}
+
+// InvalidateCPCodes invalidates all content of cpCodes on network.
+func (c *Client) InvalidateCPCodes(ctx context.Context, network Network, cpCodes ...int) ([]PurgeResponse, error) {
+	return c.Purge(ctx, Invalidate, CPCode, network, intObjects(cpCodes))
+}
+
+// DeleteCPCodes deletes all content of cpCodes from cache on network.
+func (c *Client) DeleteCPCodes(ctx context.Context, network Network, cpCodes ...int) ([]PurgeResponse, error) {
+	return c.Purge(ctx, Delete, CPCode, network, intObjects(cpCodes))
+}
+
+// InvalidateTags invalidates all content tagged with tags on network.
+func (c *Client) InvalidateTags(ctx context.Context, network Network, tags ...string) ([]PurgeResponse, error) {
+	return c.Purge(ctx, Invalidate, Tag, network, stringObjects(tags))
+}
+
+// DeleteTags deletes all content tagged with tags from cache on network.
+func (c *Client) DeleteTags(ctx context.Context, network Network, tags ...string) ([]PurgeResponse, error) {
+	return c.Purge(ctx, Delete, Tag, network, stringObjects(tags))
+}
+
+// Purge performs action on objects of objectType on network. Objects are sent in as many requests
+// as needed to respect MaxPayloadSize, and one PurgeResponse is returned per request. On error the
+// responses of the requests already accepted are returned along with the error.
+func (c *Client) Purge(ctx context.Context, action Action, objectType ObjectType, network Network, objects []interface{}) ([]PurgeResponse, error) {
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("No objects to %s", action)
+	}
+
+	batches, err := batch(objects, MaxPayloadSize)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/ccu/v3/%s/%s/%s", action, objectType, network)
+	var responses []PurgeResponse
+	for _, objects := range batches {
+		res, _, err := edgegrid.SendJSON[purgeRequest, PurgeResponse](ctx, c.client, "POST", path, purgeRequest{Objects: objects})
+		if err != nil {
+			return responses, err
+		}
+		responses = append(responses, res)
+	}
+
+	return responses, nil
+}
+
+// batch splits objects into groups whose request body encodes to at most limit bytes. The
+// overhead includes the newline json.Encoder writes after the body.
+func batch(objects []interface{}, limit int) ([][]json.RawMessage, error) {
+	const overhead = len(`{"objects":[]}` + "\n")
+
+	var (
+		batches [][]json.RawMessage
+		current []json.RawMessage
+		size    = overhead
+	)
+	for _, object := range objects {
+		encoded, err := json.Marshal(object)
+		if err != nil {
+			return nil, err
+		}
+		if overhead+len(encoded) > limit {
+			return nil, fmt.Errorf("Object %s exceeds the maximum payload size of %d bytes", encoded, limit)
+		}
+
+		added := len(encoded)
+		if len(current) > 0 {
+			added++
+		}
+		if size+added > limit {
+			batches = append(batches, current)
+			current = nil
+			size = overhead
+			added = len(encoded)
+		}
+
+		current = append(current, encoded)
+		size += added
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+
+	return batches, nil
+}
+
+func stringObjects(values []string) []interface{} {
+	objects := make([]interface{}, len(values))
+	for i, v := range values {
+		objects[i] = v
+	}
+	return objects
+}
+
+func intObjects(values []int) []interface{} {
+	objects := make([]interface{}, len(values))
+	for i, v := range values {
+		objects[i] = v
+	}
+	return objects
+}
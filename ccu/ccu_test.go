@@ -0,0 +1,121 @@
+package ccu
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/edgegridtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newServer(t *testing.T) *edgegridtest.Server {
+	s := edgegridtest.NewServer()
+	s.HandleFunc("POST /ccu/v3/{action}/{type}/{network}", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Objects []json.RawMessage `json:"objects"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(PurgeResponse{
+			HTTPStatus:       http.StatusCreated,
+			EstimatedSeconds: 5,
+			PurgeID:          fmt.Sprintf("%s-%s-%s-%d", r.PathValue("action"), r.PathValue("type"), r.PathValue("network"), len(body.Objects)),
+			Detail:           "Request accepted",
+		})
+	})
+	return s
+}
+
+func TestPurgeEndpoints(t *testing.T) {
+	s := newServer(t)
+	defer s.Close()
+	c := New(s.Client())
+	ctx := context.Background()
+
+	matrix := []struct {
+		purge   func() ([]PurgeResponse, error)
+		purgeID string
+		body    string
+	}{
+		{func() ([]PurgeResponse, error) { return c.InvalidateURLs(ctx, Staging, "https://example.com/a") }, "invalidate-url-staging-1", `{"objects":["https://example.com/a"]}`},
+		{func() ([]PurgeResponse, error) { return c.DeleteURLs(ctx, Production, "/a", "/b") }, "delete-url-production-2", `{"objects":["/a","/b"]}`},
+		{func() ([]PurgeResponse, error) { return c.InvalidateCPCodes(ctx, Production, 12345) }, "invalidate-cpcode-production-1", `{"objects":[12345]}`},
+		{func() ([]PurgeResponse, error) { return c.DeleteCPCodes(ctx, Staging, 1, 2) }, "delete-cpcode-staging-2", `{"objects":[1,2]}`},
+		{func() ([]PurgeResponse, error) { return c.InvalidateTags(ctx, Staging, "products") }, "invalidate-tag-staging-1", `{"objects":["products"]}`},
+		{func() ([]PurgeResponse, error) { return c.DeleteTags(ctx, Production, "products") }, "delete-tag-production-1", `{"objects":["products"]}`},
+	}
+	for i, tt := range matrix {
+		responses, err := tt.purge()
+		assert.NoError(t, err)
+		if assert.Len(t, responses, 1) {
+			assert.Equal(t, tt.purgeID, responses[0].PurgeID)
+			assert.Equal(t, 5, responses[0].EstimatedSeconds)
+		}
+		assert.JSONEq(t, tt.body, string(s.Requests()[i].Body))
+	}
+}
+
+func TestPurgeBatching(t *testing.T) {
+	s := newServer(t)
+	defer s.Close()
+
+	urls := make([]string, 2000)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://www.example.com/assets/images/%s/%05d.jpg", strings.Repeat("x", 20), i)
+	}
+
+	responses, err := New(s.Client()).InvalidateURLs(context.Background(), Production, urls...)
+	assert.NoError(t, err)
+	assert.True(t, len(responses) > 1)
+
+	total := 0
+	for _, req := range s.Requests() {
+		assert.True(t, len(req.Body) <= MaxPayloadSize+1, "Fail: body of %d bytes", len(req.Body))
+		var body struct {
+			Objects []string `json:"objects"`
+		}
+		assert.NoError(t, json.Unmarshal(req.Body, &body))
+		total += len(body.Objects)
+	}
+	assert.Equal(t, len(urls), total)
+}
+
+func TestBatch(t *testing.T) {
+	limit := len(`{"objects":["aaaa","bbbb"]}` + "\n")
+	batches, err := batch([]interface{}{"aaaa", "bbbb", "cccc"}, limit)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+
+	// A batch filling the limit exactly is sent with exactly limit bytes
+	var sent bytes.Buffer
+	assert.NoError(t, json.NewEncoder(&sent).Encode(purgeRequest{Objects: batches[0]}))
+	assert.Equal(t, limit, sent.Len())
+
+	batches, err = batch([]interface{}{"aaaa", "bbbb"}, limit-1)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+
+	_, err = batch([]interface{}{strings.Repeat("a", 100)}, 50)
+	assert.Error(t, err)
+}
+
+func TestPurgeErrors(t *testing.T) {
+	s := newServer(t)
+	defer s.Close()
+	s.Script("POST /ccu/v3/{action}/{type}/{network}", edgegridtest.RateLimit(0))
+
+	c := New(s.Client())
+	_, err := c.InvalidateURLs(context.Background(), Staging)
+	assert.Error(t, err)
+
+	_, err = c.InvalidateURLs(context.Background(), Staging, "/a")
+	if assert.IsType(t, &edgegrid.Error{}, err) {
+		assert.Equal(t, http.StatusTooManyRequests, err.(*edgegrid.Error).StatusCode)
+	}
+}
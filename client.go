@@ -3,8 +3,7 @@ package edgegrid
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"net/url"
 	"runtime"
@@ -15,8 +14,6 @@ const (
 	libraryVersion = "0.1.0"
 )
 
-type Response http.Response
-
 type Client struct {
 	http.Client
 
@@ -29,6 +26,10 @@ type Client struct {
 	// User agent for client
 	UserAgent string
 
+	// AccountSwitchKey, when set, is added as the accountSwitchKey query parameter of every
+	// request, so that partners and Akamai staff can act on another account.
+	AccountSwitchKey string
+
 	Config Config
 }
 
@@ -37,6 +38,7 @@ type JSONBody map[string]interface{}
 func New(httpClient *http.Client, config Config) (*Client, error) {
 	c := NewClient(httpClient)
 	c.Config = config
+	c.AccountSwitchKey = config.AccountKey
 
 	baseURL, err := url.Parse("https://" + config.Host)
 
@@ -64,6 +66,7 @@ func NewClient(httpClient *http.Client) *Client {
 
 // NewRequest creates an API request. A relative URL can be provided in urlStr, which will be resolved to the
 // BaseURL of the Client. If specified, the value pointed to by body is JSON encoded and included in as the request body.
+// An io.Reader body is sent as-is.
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
 	var req *http.Request
 
@@ -75,8 +78,28 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 	}
 
 	u := c.BaseURL.ResolveReference(rel)
-
-	req, err = http.NewRequest(method, u.String(), nil)
+	if c.AccountSwitchKey != "" && u.Query().Get("accountSwitchKey") == "" {
+		if u.RawQuery != "" {
+			u.RawQuery += "&"
+		}
+		u.RawQuery += "accountSwitchKey=" + url.QueryEscape(c.AccountSwitchKey)
+	}
+
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case io.Reader:
+		reader = b
+	default:
+		buf := new(bytes.Buffer)
+		err = json.NewEncoder(buf).Encode(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = buf
+	}
+
+	req, err = http.NewRequest(method, u.String(), reader)
 	if err != nil {
 		return nil, err
 	}
@@ -175,17 +198,3 @@ func (c *Client) Head(url string) (*Response, error) {
 
 	return response, nil
 }
-
-func (r *Response) BodyJSON(data interface{}) error {
-	if data == nil {
-		return errors.New("You must pass in an interface{}")
-	}
-
-	body, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		return err
-	}
-	err = json.Unmarshal(body, &data)
-
-	return err
-}
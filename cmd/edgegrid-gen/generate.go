@@ -0,0 +1,1063 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const edgegridImport = "github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+
+// initialisms are written in upper case in Go names, e.g. "contractId" becomes ContractID.
+var initialisms = map[string]bool{
+	"ACL": true, "API": true, "CP": true, "CPU": true, "CSR": true, "DNS": true, "EKV": true,
+	"HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true, "SQL": true, "TLS": true,
+	"TTL": true, "UI": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// generator turns a Spec into the source of a client package.
+type generator struct {
+	spec *Spec
+	pkg  string
+
+	// decls are the type declarations in output order. A slot is reserved before a struct's
+	// fields are generated so parents come before the inline types of their fields.
+	decls   []string
+	names   map[string]bool
+	imports map[string]bool
+	methods bytes.Buffer
+
+	// raw is set when an operation returns a non-JSON body, which needs the do helper.
+	raw bool
+}
+
+// operation is an API operation with its resolved parameters.
+type operation struct {
+	name   string
+	method string
+	path   string
+	op     *Operation
+
+	pathParams     []*Parameter
+	requiredParams []*Parameter
+	optionalParams []*Parameter
+}
+
+// Generate returns the formatted source of package pkg, a client for the API described by spec.
+func Generate(spec *Spec, pkg string) ([]byte, error) {
+	g := &generator{
+		spec:    spec,
+		pkg:     pkg,
+		names:   map[string]bool{"Client": true, "New": true},
+		imports: map[string]bool{},
+	}
+
+	for _, name := range spec.Components.Schemas.Keys {
+		g.names[goName(name)] = true
+	}
+	for _, name := range spec.Components.Schemas.Keys {
+		if err := g.component(name, spec.Components.Schemas.Values[name]); err != nil {
+			return nil, fmt.Errorf("Schema %s: %s", name, err)
+		}
+	}
+
+	for _, path := range spec.Paths.Keys {
+		item := spec.Paths.Values[path]
+		for _, m := range item.Operations() {
+			op, err := g.operation(m.Method, path, &item, m.Op)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %s", m.Method, path, err)
+			}
+			if err := g.method(op); err != nil {
+				return nil, fmt.Errorf("%s %s: %s", m.Method, path, err)
+			}
+		}
+	}
+
+	src := g.file()
+	formatted, err := format.Source(src)
+	if err != nil {
+		return src, fmt.Errorf("Unable to format generated code: %s", err)
+	}
+	return formatted, nil
+}
+
+// file assembles the package source.
+func (g *generator) file() []byte {
+	var b bytes.Buffer
+
+	b.WriteString("// Code generated by edgegrid-gen. DO NOT EDIT.\n\n")
+	title := g.spec.Info.Title
+	if title == "" {
+		title = "API"
+	}
+	fmt.Fprintf(&b, "// Package %s provides a client for the %s", g.pkg, title)
+	if g.spec.Info.Version != "" {
+		fmt.Fprintf(&b, " (version %s)", g.spec.Info.Version)
+	}
+	b.WriteString(".\n")
+	if g.spec.Info.Description != "" {
+		b.WriteString("//\n")
+		writeComment(&b, "", g.spec.Info.Description)
+	}
+	fmt.Fprintf(&b, "package %s\n\n", g.pkg)
+
+	g.use("context")
+	g.use("net/http")
+	g.use("net/url")
+	var std []string
+	for path := range g.imports {
+		std = append(std, path)
+	}
+	sort.Strings(std)
+	b.WriteString("import (\n")
+	for _, path := range std {
+		fmt.Fprintf(&b, "\t%q\n", path)
+	}
+	fmt.Fprintf(&b, "\n\t%q\n)\n\n", edgegridImport)
+
+	for _, decl := range g.decls {
+		b.WriteString(decl)
+		b.WriteString("\n")
+	}
+
+	b.WriteString(`// Client is a client for the API.
+type Client struct {
+	client *edgegrid.Client
+}
+
+// New creates a client sending requests with client.
+func New(client *edgegrid.Client) *Client {
+	return &Client{client: client}
+}
+
+`)
+	b.Write(g.methods.Bytes())
+
+	b.WriteString(`// newRequest creates a request for path with query added to its query string. A body other
+// than an io.Reader is sent as JSON.
+func (c *Client) newRequest(method, path string, query url.Values, body interface{}) (*http.Request, error) {
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	if body == nil {
+		return c.client.NewRequest(method, path, nil)
+	}
+	return c.client.NewJSONRequest(method, path, body)
+}
+`)
+	if g.raw {
+		b.WriteString(`
+// do sends req and returns the raw response body. Responses with a non-2xx status are returned
+// as an *edgegrid.Error.
+func (c *Client) do(ctx context.Context, req *http.Request) ([]byte, error) {
+	res, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	body, err := res.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return body, edgegrid.NewError(res.StatusCode, res.Header, body)
+	}
+	return body, nil
+}
+`)
+	}
+	return b.Bytes()
+}
+
+func (g *generator) use(path string) {
+	g.imports[path] = true
+}
+
+// component declares the named type of a components/schemas entry.
+func (g *generator) component(name string, s *Schema) error {
+	typeName := goName(name)
+
+	if s.Ref == "" && len(s.Enum) > 0 && s.Type == "string" {
+		var b bytes.Buffer
+		writeComment(&b, "", docOr(s.Description, typeName+" is an enumerated value."))
+		fmt.Fprintf(&b, "type %s string\n\n", typeName)
+		b.WriteString("const (\n")
+		for _, value := range s.Enum {
+			str, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("Unsupported enum value %v", value)
+			}
+			fmt.Fprintf(&b, "\t%s%s %s = %q\n", typeName, goName(str), typeName, str)
+		}
+		b.WriteString(")\n")
+		g.decls = append(g.decls, b.String())
+		return nil
+	}
+
+	if g.isStruct(s) && s.Ref == "" {
+		return g.structType(typeName, s)
+	}
+
+	slot := g.reserve()
+	goType, err := g.typeOf(s, typeName+"Item")
+	if err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	writeComment(&b, "", docOr(s.Description, typeName+" is the "+name+" schema of the API."))
+	fmt.Fprintf(&b, "type %s %s\n", typeName, goType)
+	g.decls[slot] = b.String()
+	return nil
+}
+
+// reserve reserves a declaration slot and returns its index.
+func (g *generator) reserve() int {
+	g.decls = append(g.decls, "")
+	return len(g.decls) - 1
+}
+
+// uniqueName returns name, or name with a numeric suffix if it is taken, and marks it taken.
+func (g *generator) uniqueName(name string) string {
+	unique := name
+	for i := 2; g.names[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	g.names[unique] = true
+	return unique
+}
+
+// resolve follows a reference to a component schema.
+func (g *generator) resolve(s *Schema) (*Schema, error) {
+	visited := map[string]bool{}
+	for s != nil && s.Ref != "" {
+		if visited[s.Ref] {
+			return nil, fmt.Errorf("Circular reference %s", s.Ref)
+		}
+		visited[s.Ref] = true
+
+		name, ok := refName(s.Ref, "schemas")
+		if !ok {
+			return nil, fmt.Errorf("Unsupported reference %s", s.Ref)
+		}
+		target, ok := g.spec.Components.Schemas.Values[name]
+		if !ok {
+			return nil, fmt.Errorf("Unknown schema %s", s.Ref)
+		}
+		s = target
+	}
+	return s, nil
+}
+
+// isStruct reports whether s, after resolving references, becomes a Go struct.
+func (g *generator) isStruct(s *Schema) bool {
+	s, err := g.resolve(s)
+	if err != nil || s == nil {
+		return false
+	}
+	return len(s.Properties.Keys) > 0 || len(s.AllOf) > 1 || (len(s.AllOf) == 1 && s.AllOf[0].Ref == "")
+}
+
+// typeOf returns the Go type of s. Inline objects are declared as structs named name.
+func (g *generator) typeOf(s *Schema, name string) (string, error) {
+	if s == nil {
+		return "interface{}", nil
+	}
+
+	if s.Ref != "" {
+		if _, err := g.resolve(s); err != nil {
+			return "", err
+		}
+		ref, _ := refName(s.Ref, "schemas")
+		return goName(ref), nil
+	}
+
+	if len(s.AllOf) == 1 && s.AllOf[0].Ref != "" && len(s.Properties.Keys) == 0 {
+		return g.typeOf(s.AllOf[0], name)
+	}
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		g.use("encoding/json")
+		return "json.RawMessage", nil
+	}
+	if g.isStruct(s) {
+		typeName := g.uniqueName(name)
+		if err := g.structType(typeName, s); err != nil {
+			return "", err
+		}
+		return typeName, nil
+	}
+
+	switch s.Type {
+	case "string":
+		return "string", nil
+	case "integer":
+		if s.Format == "int64" {
+			return "int64", nil
+		}
+		return "int", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		item, err := g.typeOf(s.Items, name+"Item")
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case "object", "":
+		if s.AdditionalProperties != nil {
+			value, err := g.typeOf(s.AdditionalProperties, name+"Value")
+			if err != nil {
+				return "", err
+			}
+			return "map[string]" + value, nil
+		}
+		if s.Type == "object" {
+			return "map[string]interface{}", nil
+		}
+		return "interface{}", nil
+	}
+	return "", fmt.Errorf("Unsupported type %q", s.Type)
+}
+
+// structType declares a struct named typeName for the object schema s. The properties of allOf
+// references are embedded; inline allOf parts are merged into the struct.
+func (g *generator) structType(typeName string, s *Schema) error {
+	slot := g.reserve()
+
+	var fields bytes.Buffer
+	parts := append([]*Schema{s}, s.AllOf...)
+	for _, part := range parts {
+		if part.Ref != "" {
+			embedded, err := g.typeOf(part, typeName)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&fields, "\t%s\n", embedded)
+			continue
+		}
+
+		for _, prop := range part.Properties.Keys {
+			schema := part.Properties.Values[prop]
+			fieldName := goName(prop)
+			fieldType, err := g.typeOf(schema, typeName+fieldName)
+			if err != nil {
+				return fmt.Errorf("%s: %s", prop, err)
+			}
+
+			tag := prop
+			if !part.IsRequired(prop) && !s.IsRequired(prop) {
+				tag += ",omitempty"
+				if g.isStruct(schema) {
+					fieldType = "*" + fieldType
+				}
+			}
+
+			if description := schemaDescription(schema); description != "" {
+				writeComment(&fields, "\t", description)
+			}
+			fmt.Fprintf(&fields, "\t%s %s `json:%q`\n", fieldName, fieldType, tag)
+		}
+	}
+
+	var b bytes.Buffer
+	writeComment(&b, "", docOr(s.Description, typeName+" is an object of the API."))
+	fmt.Fprintf(&b, "type %s struct {\n%s}\n", typeName, fields.String())
+	g.decls[slot] = b.String()
+	return nil
+}
+
+// operation resolves the parameters of op and names it.
+func (g *generator) operation(method, path string, item *PathItem, op *Operation) (*operation, error) {
+	name := goName(op.OperationID)
+	if op.OperationID == "" {
+		name = goName(strings.ToLower(method) + " " + strings.NewReplacer("{", "", "}", "").Replace(path))
+	}
+	o := &operation{name: name, method: method, path: path, op: op}
+
+	var params []*Parameter
+	for _, p := range append(append([]*Parameter{}, item.Parameters...), op.Parameters...) {
+		p, err := g.parameter(p)
+		if err != nil {
+			return nil, err
+		}
+		replaced := false
+		for i, existing := range params {
+			if existing.Name == p.Name && existing.In == p.In {
+				params[i] = p
+				replaced = true
+			}
+		}
+		if !replaced {
+			params = append(params, p)
+		}
+	}
+
+	for _, p := range params {
+		switch {
+		case p.In == "path":
+			o.pathParams = append(o.pathParams, p)
+		case p.In != "query" && p.In != "header":
+			return nil, fmt.Errorf("Unsupported %s parameter %s", p.In, p.Name)
+		case p.Required:
+			o.requiredParams = append(o.requiredParams, p)
+		default:
+			o.optionalParams = append(o.optionalParams, p)
+		}
+	}
+	return o, nil
+}
+
+// parameter follows a reference to a component parameter.
+func (g *generator) parameter(p *Parameter) (*Parameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+	name, ok := refName(p.Ref, "parameters")
+	if !ok || g.spec.Components.Parameters[name] == nil {
+		return nil, fmt.Errorf("Unknown parameter %s", p.Ref)
+	}
+	return g.spec.Components.Parameters[name], nil
+}
+
+// requestBody returns the body of op, following references.
+func (g *generator) requestBody(op *Operation) (*RequestBody, error) {
+	body := op.RequestBody
+	if body == nil || body.Ref == "" {
+		return body, nil
+	}
+	name, ok := refName(body.Ref, "requestBodies")
+	if !ok || g.spec.Components.RequestBodies[name] == nil {
+		return nil, fmt.Errorf("Unknown request body %s", body.Ref)
+	}
+	return g.spec.Components.RequestBodies[name], nil
+}
+
+// response returns the first 2xx response of op, following references.
+func (g *generator) response(op *Operation) (*Response, error) {
+	for _, code := range op.Responses.Keys {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		res := op.Responses.Values[code]
+		if res.Ref != "" {
+			name, ok := refName(res.Ref, "responses")
+			if !ok || g.spec.Components.Responses[name] == nil {
+				return nil, fmt.Errorf("Unknown response %s", res.Ref)
+			}
+			res = g.spec.Components.Responses[name]
+		}
+		return res, nil
+	}
+	return nil, nil
+}
+
+// mediaType picks the JSON media type of content, or else any other, reporting whether it is JSON.
+func mediaType(content map[string]MediaType) (string, *MediaType, bool) {
+	var types []string
+	for contentType := range content {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+
+	for _, contentType := range types {
+		if contentType == "application/json" || strings.HasSuffix(contentType, "+json") {
+			mt := content[contentType]
+			return contentType, &mt, true
+		}
+	}
+	if len(types) > 0 {
+		mt := content[types[0]]
+		return types[0], &mt, false
+	}
+	return "", nil, false
+}
+
+// method writes the client method of o, and its Params struct and paginated variant if any.
+func (g *generator) method(o *operation) error {
+	var (
+		args []string
+		vars = map[string]bool{
+			"c": true, "ctx": true, "params": true, "body": true, "payload": true, "query": true, "req": true,
+			"err": true, "result": true, "v": true, "path": true, "strategy": true, "pageSize": true,
+		}
+	)
+	argNames := map[*Parameter]string{}
+	addArg := func(p *Parameter) error {
+		goType, err := g.typeOf(p.Schema, o.name+goName(p.Name))
+		if err != nil {
+			return fmt.Errorf("Parameter %s: %s", p.Name, err)
+		}
+		name := varName(p.Name)
+		for vars[name] {
+			name += "_"
+		}
+		vars[name] = true
+		argNames[p] = name
+		args = append(args, name+" "+goType)
+		return nil
+	}
+	for _, p := range o.pathParams {
+		if err := addArg(p); err != nil {
+			return err
+		}
+	}
+	for _, p := range o.requiredParams {
+		if err := addArg(p); err != nil {
+			return err
+		}
+	}
+
+	// Optional query and header parameters are fields of a Params struct, sent unless zero.
+	paramsType := ""
+	if len(o.optionalParams) > 0 {
+		paramsType = g.uniqueName(o.name + "Params")
+		var b bytes.Buffer
+		fmt.Fprintf(&b, "// %s holds the optional parameters of %s. Zero values are not sent.\n", paramsType, o.name)
+		fmt.Fprintf(&b, "type %s struct {\n", paramsType)
+		for _, p := range o.optionalParams {
+			goType, err := g.typeOf(p.Schema, o.name+goName(p.Name))
+			if err != nil {
+				return fmt.Errorf("Parameter %s: %s", p.Name, err)
+			}
+			if p.Description != "" {
+				writeComment(&b, "\t", p.Description)
+			}
+			fmt.Fprintf(&b, "\t%s %s\n", goName(p.Name), goType)
+		}
+		b.WriteString("}\n")
+		g.decls = append(g.decls, b.String())
+		args = append(args, "params "+paramsType)
+	}
+
+	body, err := g.requestBody(o.op)
+	if err != nil {
+		return err
+	}
+	bodyContentType, bodyJSON, bodyPointer := "", false, false
+	if body != nil {
+		contentType, mt, isJSON := mediaType(body.Content)
+		bodyContentType, bodyJSON = contentType, isJSON
+		bodyType := "io.Reader"
+		if isJSON {
+			bodyType, err = g.typeOf(mt.Schema, o.name+"Request")
+			if err != nil {
+				return fmt.Errorf("Request body: %s", err)
+			}
+			if g.isStruct(mt.Schema) {
+				bodyType = "*" + bodyType
+				bodyPointer = true
+			}
+		} else {
+			g.use("io")
+		}
+		args = append(args, "body "+bodyType)
+	}
+
+	res, err := g.response(o.op)
+	if err != nil {
+		return err
+	}
+	resultType, resultJSON, resultPointer := "", false, false
+	var resultSchema *Schema
+	if res != nil {
+		if _, mt, isJSON := mediaType(res.Content); mt != nil {
+			resultJSON = isJSON
+			if isJSON {
+				resultSchema = mt.Schema
+				resultType, err = g.typeOf(mt.Schema, o.name+"Response")
+				if err != nil {
+					return fmt.Errorf("Response: %s", err)
+				}
+				resultPointer = g.isStruct(mt.Schema)
+			} else {
+				g.raw = true
+				resultType = "[]byte"
+			}
+		}
+	}
+
+	// Signature and doc comment.
+	w := &g.methods
+	fmt.Fprintf(w, "// %s calls %s %s.\n", o.name, o.method, o.path)
+	if doc := docOr(o.op.Summary, o.op.Description); doc != "" {
+		w.WriteString("//\n")
+		writeComment(w, "", doc)
+	}
+	if o.op.Deprecated {
+		w.WriteString("//\n// Deprecated: the API marks this operation as deprecated.\n")
+	}
+	returns := "error"
+	zero := ""
+	switch {
+	case resultPointer:
+		returns = "(*" + resultType + ", error)"
+		zero = "nil, "
+	case !resultJSON && resultType != "":
+		returns = "(" + resultType + ", error)"
+		zero = "nil, "
+	case resultType != "":
+		returns = "(" + resultType + ", error)"
+		zero = "result, "
+	}
+	fmt.Fprintf(w, "func (c *Client) %s(%s) %s {\n", o.name, strings.Join(append([]string{"ctx context.Context"}, args...), ", "), returns)
+	if zero == "result, " {
+		fmt.Fprintf(w, "\tvar result %s\n", resultType)
+	}
+
+	query, err := g.writeQuery(w, o, argNames, "", "")
+	if err != nil {
+		return err
+	}
+
+	payload := "nil"
+	if body != nil {
+		payload = "body"
+		if bodyPointer && !body.Required {
+			w.WriteString("\tvar payload interface{}\n\tif body != nil {\n\t\tpayload = body\n\t}\n")
+			payload = "payload"
+		}
+	}
+
+	pathExpr, err := g.pathExpr(o, argNames)
+	if err != nil {
+		return err
+	}
+	method := strconv.Quote(o.method)
+	if body != nil && !bodyJSON {
+		// A non-JSON body is sent as-is with its content type.
+		fmt.Fprintf(w, "\treq, err := c.client.NewRequest(%s, %s, body)\n", method, withQueryExpr(pathExpr, query))
+		fmt.Fprintf(w, "\tif err != nil {\n\t\treturn %serr\n\t}\n", zero)
+		fmt.Fprintf(w, "\treq.Header.Set(\"Content-Type\", %q)\n", bodyContentType)
+	} else {
+		fmt.Fprintf(w, "\treq, err := c.newRequest(%s, %s, %s, %s)\n", method, pathExpr, query, payload)
+		fmt.Fprintf(w, "\tif err != nil {\n\t\treturn %serr\n\t}\n", zero)
+	}
+	if err := g.writeHeaders(w, o, argNames); err != nil {
+		return err
+	}
+
+	switch {
+	case resultType == "":
+		w.WriteString("\t_, _, err = edgegrid.DoJSON[struct{}](ctx, c.client, req)\n\treturn err\n")
+	case !resultJSON:
+		w.WriteString("\treturn c.do(ctx, req)\n")
+	case resultPointer:
+		fmt.Fprintf(w, "\tresult, _, err := edgegrid.DoJSON[%s](ctx, c.client, req)\n", resultType)
+		w.WriteString("\tif err != nil {\n\t\treturn nil, err\n\t}\n\treturn &result, nil\n")
+	default:
+		fmt.Fprintf(w, "\tresult, _, err = edgegrid.DoJSON[%s](ctx, c.client, req)\n", resultType)
+		w.WriteString("\treturn result, err\n")
+	}
+	w.WriteString("}\n\n")
+
+	return g.paginated(o, args, argNames, paramsType, resultSchema, pathExpr)
+}
+
+// writeQuery writes the statements building the query of o and returns the expression holding
+// it. Parameters in skip are left to a paginator.
+func (g *generator) writeQuery(w *bytes.Buffer, o *operation, argNames map[*Parameter]string, skip1, skip2 string) (string, error) {
+	var required, optional []*Parameter
+	for _, p := range o.requiredParams {
+		if p.In == "query" && p.Name != skip1 && p.Name != skip2 {
+			required = append(required, p)
+		}
+	}
+	for _, p := range o.optionalParams {
+		if p.In == "query" && p.Name != skip1 && p.Name != skip2 {
+			optional = append(optional, p)
+		}
+	}
+	if len(required)+len(optional) == 0 {
+		return "nil", nil
+	}
+
+	w.WriteString("\tquery := url.Values{}\n")
+	for _, p := range required {
+		if err := g.writeSet(w, "\t", "query.Add", p, argNames[p]); err != nil {
+			return "", err
+		}
+	}
+	for _, p := range optional {
+		if err := g.writeOptional(w, "query.Add", p); err != nil {
+			return "", err
+		}
+	}
+	return "query", nil
+}
+
+// writeHeaders writes the statements setting the header parameters of o on req.
+func (g *generator) writeHeaders(w *bytes.Buffer, o *operation, argNames map[*Parameter]string) error {
+	for _, p := range o.requiredParams {
+		if p.In == "header" {
+			if err := g.writeSet(w, "\t", "req.Header.Add", p, argNames[p]); err != nil {
+				return err
+			}
+		}
+	}
+	for _, p := range o.optionalParams {
+		if p.In == "header" {
+			if err := g.writeOptional(w, "req.Header.Add", p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeOptional writes a statement adding the optional parameter p from params unless it is zero.
+func (g *generator) writeOptional(w *bytes.Buffer, add string, p *Parameter) error {
+	field := "params." + goName(p.Name)
+	schema, err := g.resolve(p.Schema)
+	if err != nil {
+		return err
+	}
+
+	if schema != nil && schema.Type == "array" {
+		// Ranging over an empty slice adds nothing.
+		return g.writeSet(w, "\t", add, p, field)
+	}
+
+	condition := field + " != " + zeroValue(schema)
+	if schema != nil && schema.Type == "boolean" {
+		condition = field
+	}
+	fmt.Fprintf(w, "\tif %s {\n", condition)
+	if err := g.writeSet(w, "\t\t", add, p, field); err != nil {
+		return err
+	}
+	w.WriteString("\t}\n")
+	return nil
+}
+
+// writeSet writes a statement adding the value expr of parameter p with add, e.g. query.Add.
+func (g *generator) writeSet(w *bytes.Buffer, indent, add string, p *Parameter, expr string) error {
+	schema, err := g.resolve(p.Schema)
+	if err != nil {
+		return err
+	}
+	if schema != nil && schema.Type == "array" {
+		item, err := g.resolve(schema.Items)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%sfor _, v := range %s {\n", indent, expr)
+		fmt.Fprintf(w, "%s\t%s(%q, %s)\n", indent, add, p.Name, g.stringExpr("v", schema.Items, item))
+		fmt.Fprintf(w, "%s}\n", indent)
+		return nil
+	}
+	fmt.Fprintf(w, "%s%s(%q, %s)\n", indent, add, p.Name, g.stringExpr(expr, p.Schema, schema))
+	return nil
+}
+
+// stringExpr returns an expression formatting expr, of the type of schema, as a string. resolved
+// is schema with references followed.
+func (g *generator) stringExpr(expr string, schema, resolved *Schema) string {
+	if resolved == nil {
+		g.use("fmt")
+		return "fmt.Sprint(" + expr + ")"
+	}
+	switch resolved.Type {
+	case "string":
+		if schema.Ref != "" {
+			return "string(" + expr + ")"
+		}
+		return expr
+	case "integer":
+		g.use("strconv")
+		if schema.Ref != "" {
+			return "strconv.FormatInt(int64(" + expr + "), 10)"
+		}
+		if resolved.Format == "int64" {
+			return "strconv.FormatInt(" + expr + ", 10)"
+		}
+		return "strconv.Itoa(" + expr + ")"
+	case "number":
+		g.use("strconv")
+		if schema.Ref != "" {
+			expr = "float64(" + expr + ")"
+		}
+		return "strconv.FormatFloat(" + expr + ", 'f', -1, 64)"
+	case "boolean":
+		g.use("strconv")
+		if schema.Ref != "" {
+			expr = "bool(" + expr + ")"
+		}
+		return "strconv.FormatBool(" + expr + ")"
+	}
+	g.use("fmt")
+	return "fmt.Sprint(" + expr + ")"
+}
+
+// pathExpr returns an expression building the path of o with escaped path parameters.
+func (g *generator) pathExpr(o *operation, argNames map[*Parameter]string) (string, error) {
+	var parts []string
+	rest := o.path
+	for {
+		start := strings.Index(rest, "{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return "", fmt.Errorf("Unterminated path parameter in %s", o.path)
+		}
+		end += start
+
+		name := rest[start+1 : end]
+		var param *Parameter
+		for _, p := range o.pathParams {
+			if p.Name == name {
+				param = p
+			}
+		}
+		if param == nil {
+			return "", fmt.Errorf("Undeclared path parameter %s", name)
+		}
+		schema, err := g.resolve(param.Schema)
+		if err != nil {
+			return "", err
+		}
+
+		if rest[:start] != "" {
+			parts = append(parts, strconv.Quote(rest[:start]))
+		}
+		parts = append(parts, "url.PathEscape("+g.stringExpr(argNames[param], param.Schema, schema)+")")
+		rest = rest[end+1:]
+	}
+	if rest != "" || len(parts) == 0 {
+		parts = append(parts, strconv.Quote(rest))
+	}
+	return strings.Join(parts, " + "), nil
+}
+
+func withQueryExpr(pathExpr, query string) string {
+	if query == "nil" {
+		return pathExpr
+	}
+	return pathExpr + ` + "?" + ` + query + ".Encode()"
+}
+
+// paginated writes an <Op>All method iterating over every item of a paged GET operation. An
+// operation is paged when it takes offset and limit or page and pageSize query parameters and
+// returns an array, either directly or as the only array property of an object.
+func (g *generator) paginated(o *operation, args []string, argNames map[*Parameter]string, paramsType string, resultSchema *Schema, pathExpr string) error {
+	if o.method != "GET" || resultSchema == nil {
+		return nil
+	}
+
+	query := map[string]bool{}
+	for _, p := range o.optionalParams {
+		if p.In == "query" {
+			query[p.Name] = true
+		}
+	}
+	var strategy, first, second string
+	switch {
+	case query["offset"] && query["limit"]:
+		strategy = `&edgegrid.OffsetLimit{OffsetParam: "offset", LimitParam: "limit", Limit: pageSize}`
+		first, second = "offset", "limit"
+	case query["page"] && query["pageSize"]:
+		strategy = `&edgegrid.PageNumber{PageParam: "page", SizeParam: "pageSize", Size: pageSize, FirstPage: 1}`
+		first, second = "page", "pageSize"
+	default:
+		return nil
+	}
+	for _, p := range o.optionalParams {
+		if p.In == "header" {
+			// The paginator cannot send headers.
+			return nil
+		}
+	}
+
+	resolved, err := g.resolve(resultSchema)
+	if err != nil {
+		return err
+	}
+	var items *Schema
+	keys := ""
+	if resolved.Type == "array" {
+		items = resolved.Items
+	} else {
+		for _, prop := range resolved.Properties.Keys {
+			schema, err := g.resolve(resolved.Properties.Values[prop])
+			if err != nil {
+				return err
+			}
+			if schema.Type == "array" {
+				if items != nil {
+					return nil
+				}
+				items = schema.Items
+				keys = strconv.Quote(prop)
+			}
+		}
+	}
+	if items == nil {
+		return nil
+	}
+	itemType, err := g.typeOf(items, o.name+"Item")
+	if err != nil {
+		return err
+	}
+
+	var fixed []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "body ") {
+			fixed = append(fixed, arg)
+		}
+	}
+	fixed = append(fixed, "pageSize int")
+
+	g.use("iter")
+	w := &g.methods
+	fmt.Fprintf(w, "// %sAll returns an iterator over every item of %s, requesting pages of pageSize items.\n", o.name, o.name)
+	fmt.Fprintf(w, "// Iteration stops after the first error.\n")
+	fmt.Fprintf(w, "func (c *Client) %sAll(%s) iter.Seq2[%s, error] {\n", o.name, strings.Join(append([]string{"ctx context.Context"}, fixed...), ", "), itemType)
+	query2, err := g.writeQuery(w, o, argNames, first, second)
+	if err != nil {
+		return err
+	}
+	path := pathExpr
+	if query2 != "nil" {
+		fmt.Fprintf(w, "\tpath := %s\n\tif len(query) > 0 {\n\t\tpath += \"?\" + query.Encode()\n\t}\n", pathExpr)
+		path = "path"
+	}
+	fmt.Fprintf(w, "\tstrategy := %s\n", strategy)
+	fmt.Fprintf(w, "\treturn edgegrid.NewPaginator(c.client, %s, strategy, edgegrid.ItemsAt[%s](%s)).All(ctx)\n", path, itemType, keys)
+	w.WriteString("}\n\n")
+	return nil
+}
+
+// refName returns the name of a local reference to a component of kind, e.g. "Zone" from
+// "#/components/schemas/Zone".
+func refName(ref, kind string) (string, bool) {
+	prefix := "#/components/" + kind + "/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(ref, prefix), true
+}
+
+func schemaDescription(s *Schema) string {
+	if s == nil {
+		return ""
+	}
+	return s.Description
+}
+
+func docOr(doc, fallback string) string {
+	if strings.TrimSpace(doc) != "" {
+		return doc
+	}
+	return fallback
+}
+
+// zeroValue returns the zero value literal of the type of the resolved schema s.
+func zeroValue(s *Schema) string {
+	if s == nil {
+		return "nil"
+	}
+	switch s.Type {
+	case "string":
+		return `""`
+	case "integer", "number":
+		return "0"
+	case "boolean":
+		return "false"
+	}
+	return "nil"
+}
+
+// writeComment writes text as a comment, one line per line of text.
+func writeComment(b *bytes.Buffer, indent, text string) {
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			fmt.Fprintf(b, "%s//\n", indent)
+			continue
+		}
+		fmt.Fprintf(b, "%s// %s\n", indent, line)
+	}
+}
+
+// words splits a name into words at separators and case changes, e.g. "cpCodeID" into
+// "cp", "Code", "ID".
+func words(name string) []string {
+	var (
+		result []string
+		word   []rune
+	)
+	runes := []rune(name)
+	flush := func() {
+		if len(word) > 0 {
+			result = append(result, string(word))
+			word = nil
+		}
+	}
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := word[len(word)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return result
+}
+
+// goName returns the exported Go name of name, e.g. ContractID for "contractId".
+func goName(name string) string {
+	var b strings.Builder
+	for _, word := range words(name) {
+		upper := strings.ToUpper(word)
+		if initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	result := b.String()
+	if result == "" {
+		return "X"
+	}
+	if unicode.IsDigit([]rune(result)[0]) {
+		result = "X" + result
+	}
+	return result
+}
+
+// varName returns the unexported Go name of name, e.g. contractID for "contractId".
+func varName(name string) string {
+	exported := []rune(goName(name))
+	upper := 0
+	for upper < len(exported) && unicode.IsUpper(exported[upper]) {
+		upper++
+	}
+	switch {
+	case upper == len(exported):
+		// All upper case, e.g. ID.
+	case upper > 1:
+		// Keep the first letter of the next word, e.g. URLPath becomes urlPath.
+		upper--
+	}
+	result := strings.ToLower(string(exported[:upper])) + string(exported[upper:])
+	if token.IsKeyword(result) {
+		result += "_"
+	}
+	return result
+}
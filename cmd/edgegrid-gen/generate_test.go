@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestGenerateGolden(t *testing.T) {
+	specs, err := filepath.Glob("testdata/*.json")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, specs)
+
+	for _, specPath := range specs {
+		name := strings.TrimSuffix(filepath.Base(specPath), ".json")
+		t.Run(name, func(t *testing.T) {
+			data, err := ioutil.ReadFile(specPath)
+			assert.NoError(t, err)
+			spec, err := ParseSpec(data)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			src, err := Generate(spec, name)
+			if !assert.NoError(t, err, string(src)) {
+				return
+			}
+
+			golden := filepath.Join("testdata", name+".golden")
+			if *update {
+				assert.NoError(t, ioutil.WriteFile(golden, src, 0644))
+				return
+			}
+			expected, err := ioutil.ReadFile(golden)
+			assert.NoError(t, err)
+			assert.Equal(t, string(expected), string(src))
+		})
+	}
+}
+
+func TestParseSpecVersion(t *testing.T) {
+	_, err := ParseSpec([]byte(`{"swagger":"2.0"}`))
+	assert.Error(t, err)
+}
+
+func TestGoName(t *testing.T) {
+	matrix := []struct {
+		in, name, variable string
+	}{
+		{"contractId", "ContractID", "contractID"},
+		{"cpCode", "CPCode", "cpCode"},
+		{"list-namespaces", "ListNamespaces", "listNamespaces"},
+		{"URLPath", "URLPath", "urlPath"},
+		{"id", "ID", "id"},
+		{"page_size", "PageSize", "pageSize"},
+		{"type", "Type", "type_"},
+		{"2fa", "X2fa", "x2fa"},
+	}
+	for _, tt := range matrix {
+		assert.Equal(t, tt.name, goName(tt.in), tt.in)
+		assert.Equal(t, tt.variable, varName(tt.in), tt.in)
+	}
+}
+
+func TestGenerateCircularReference(t *testing.T) {
+	spec, err := ParseSpec([]byte(`{
+		"openapi": "3.0.0",
+		"info": {"title": "Circular", "version": "1"},
+		"paths": {
+			"/items": {
+				"get": {
+					"operationId": "getItem",
+					"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/A"}}}}}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"A": {"$ref": "#/components/schemas/B"},
+				"B": {"$ref": "#/components/schemas/A"}
+			}
+		}
+	}`))
+	assert.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := Generate(spec, "circular")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.EqualError(t, err, "Schema A: Circular reference #/components/schemas/B")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Fail: Generate does not terminate on a circular reference")
+	}
+}
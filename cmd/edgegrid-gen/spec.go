@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Spec is the subset of an OpenAPI 3 document the generator uses.
+type Spec struct {
+	OpenAPI    string            `json:"openapi"`
+	Info       Info              `json:"info"`
+	Paths      Ordered[PathItem] `json:"paths"`
+	Components Components        `json:"components"`
+}
+
+// Info is the info object of a spec.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+// Components holds the reusable objects of a spec.
+type Components struct {
+	Schemas       Ordered[*Schema]        `json:"schemas"`
+	Parameters    map[string]*Parameter   `json:"parameters"`
+	RequestBodies map[string]*RequestBody `json:"requestBodies"`
+	Responses     map[string]*Response    `json:"responses"`
+}
+
+// PathItem holds the operations of a path.
+type PathItem struct {
+	Parameters []*Parameter `json:"parameters"`
+	Get        *Operation   `json:"get"`
+	Put        *Operation   `json:"put"`
+	Post       *Operation   `json:"post"`
+	Delete     *Operation   `json:"delete"`
+	Patch      *Operation   `json:"patch"`
+}
+
+// Operations returns the operations of the path item by method, in a fixed order.
+func (p *PathItem) Operations() []struct {
+	Method string
+	Op     *Operation
+} {
+	var ops []struct {
+		Method string
+		Op     *Operation
+	}
+	for _, m := range []struct {
+		method string
+		op     *Operation
+	}{{"GET", p.Get}, {"POST", p.Post}, {"PUT", p.Put}, {"PATCH", p.Patch}, {"DELETE", p.Delete}} {
+		if m.op != nil {
+			ops = append(ops, struct {
+				Method string
+				Op     *Operation
+			}{m.method, m.op})
+		}
+	}
+	return ops
+}
+
+// Operation is an API operation.
+type Operation struct {
+	OperationID string             `json:"operationId"`
+	Summary     string             `json:"summary"`
+	Description string             `json:"description"`
+	Deprecated  bool               `json:"deprecated"`
+	Parameters  []*Parameter       `json:"parameters"`
+	RequestBody *RequestBody       `json:"requestBody"`
+	Responses   Ordered[*Response] `json:"responses"`
+}
+
+// Parameter is a path, query or header parameter.
+type Parameter struct {
+	Ref         string  `json:"$ref"`
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is the body of an operation.
+type RequestBody struct {
+	Ref         string               `json:"$ref"`
+	Description string               `json:"description"`
+	Required    bool                 `json:"required"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// Response is a response of an operation.
+type Response struct {
+	Ref         string               `json:"$ref"`
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// MediaType holds the schema of a body for a content type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a JSON schema.
+type Schema struct {
+	Ref                  string           `json:"$ref"`
+	Type                 string           `json:"type"`
+	Format               string           `json:"format"`
+	Description          string           `json:"description"`
+	Enum                 []interface{}    `json:"enum"`
+	Default              interface{}      `json:"default"`
+	Properties           Ordered[*Schema] `json:"properties"`
+	Required             []string         `json:"required"`
+	Items                *Schema          `json:"items"`
+	AdditionalProperties *Schema          `json:"-"`
+	AllOf                []*Schema        `json:"allOf"`
+	OneOf                []*Schema        `json:"oneOf"`
+	AnyOf                []*Schema        `json:"anyOf"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. additionalProperties may be a boolean or a schema.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type schemaAlias Schema
+	var raw struct {
+		schemaAlias
+		AdditionalProperties json.RawMessage `json:"additionalProperties"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*s = Schema(raw.schemaAlias)
+
+	switch strings.TrimSpace(string(raw.AdditionalProperties)) {
+	case "", "false":
+	case "true":
+		s.AdditionalProperties = &Schema{}
+	default:
+		s.AdditionalProperties = &Schema{}
+		if err := json.Unmarshal(raw.AdditionalProperties, s.AdditionalProperties); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsRequired reports whether the property name is required.
+func (s *Schema) IsRequired(name string) bool {
+	for _, required := range s.Required {
+		if required == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Ordered is a JSON object that remembers the order of its keys, so that generated code follows
+// the order of the spec.
+type Ordered[T any] struct {
+	Keys   []string
+	Values map[string]T
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (o *Ordered[T]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("Expected an object, got %v", token)
+	}
+
+	o.Keys = nil
+	o.Values = map[string]T{}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := token.(string)
+
+		var value T
+		if err := dec.Decode(&value); err != nil {
+			return fmt.Errorf("%s: %s", key, err)
+		}
+		o.Keys = append(o.Keys, key)
+		o.Values[key] = value
+	}
+	return nil
+}
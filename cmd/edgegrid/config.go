@@ -0,0 +1,428 @@
+package main
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// edgeRcKeys are the keys of an .edgerc section in the order they are shown and prompted for.
+var edgeRcKeys = []struct {
+	name     string
+	required bool
+}{
+	{"host", true},
+	{"client_token", true},
+	{"client_secret", true},
+	{"access_token", true},
+	{"account_key", false},
+	{"max_body", false},
+	{"headers_to_sign", false},
+}
+
+// defaultTestEndpoint is requested by config test. It answers any valid credential.
+const defaultTestEndpoint = "/identity-management/v3/api-clients/self"
+
+// config runs the config subcommand, managing the sections of the .edgerc file.
+func (c *cli) config(args []string) int {
+	actions := map[string]func(c *cli, args []string) int{
+		"list":   (*cli).configList,
+		"show":   (*cli).configShow,
+		"add":    (*cli).configAdd,
+		"set":    (*cli).configSet,
+		"remove": (*cli).configRemove,
+		"test":   (*cli).configTest,
+	}
+	if len(args) == 0 || actions[args[0]] == nil {
+		fmt.Fprintln(c.stderr, "Usage: edgegrid config list|show|add|set|remove|test [flags]")
+		return 2
+	}
+	return actions[args[0]](c, args[1:])
+}
+
+// configList lists the sections of the .edgerc file with their hosts.
+func (c *cli) configList(args []string) int {
+	var creds credentialFlags
+	flags := c.newFlagSet("config list", "edgegrid config list [flags]")
+	creds.register(flags)
+	if _, err := parseInterspersed(flags, args); err != nil {
+		return 2
+	}
+
+	sections, err := edgegrid.EdgeRcSections(creds.edgerc)
+	if err != nil {
+		return c.fail(err)
+	}
+	for _, section := range sections {
+		host := ""
+		if config, err := edgegrid.InitEdgeRc(creds.edgerc, section); err == nil {
+			host = config.Host
+		}
+		fmt.Fprintf(c.stdout, "%-20s %s\n", section, host)
+	}
+	return 0
+}
+
+// configShow prints the credentials the other commands use, with the client secret redacted.
+func (c *cli) configShow(args []string) int {
+	var (
+		creds  credentialFlags
+		reveal bool
+	)
+	flags := c.newFlagSet("config show", "edgegrid config show [flags]")
+	creds.register(flags)
+	flags.BoolVar(&reveal, "reveal", false, "print the client secret")
+	if _, err := parseInterspersed(flags, args); err != nil {
+		return 2
+	}
+
+	config, err := creds.config()
+	if err != nil {
+		return c.fail(err)
+	}
+	secret := config.ClientSecret
+	if !reveal {
+		secret = redact(secret)
+	}
+	values := map[string]string{
+		"host":            config.Host,
+		"client_token":    config.ClientToken,
+		"client_secret":   secret,
+		"access_token":    config.AccessToken,
+		"account_key":     config.AccountKey,
+		"max_body":        strconv.Itoa(config.MaxBody),
+		"headers_to_sign": strings.Join(config.HeaderToSign, ","),
+	}
+	for _, key := range edgeRcKeys {
+		fmt.Fprintln(c.stdout, strings.TrimRight(fmt.Sprintf("%-16s %s", key.name, values[key.name]), " "))
+	}
+	return 0
+}
+
+// configAdd adds a section, prompting for the values not given as flags.
+func (c *cli) configAdd(args []string) int {
+	var (
+		creds  credentialFlags
+		force  bool
+		values = map[string]*string{}
+	)
+	flags := c.newFlagSet("config add", "edgegrid config add [flags]")
+	creds.register(flags)
+	flags.BoolVar(&force, "force", false, "replace an existing section, removing the keys not given")
+	for _, key := range edgeRcKeys {
+		values[key.name] = flags.String(strings.Replace(key.name, "_", "-", -1), "", "`value` of "+key.name)
+	}
+	if _, err := parseInterspersed(flags, args); err != nil {
+		return 2
+	}
+
+	if sections, err := edgegrid.EdgeRcSections(creds.edgerc); err == nil && !force {
+		for _, section := range sections {
+			if section == creds.section {
+				return c.fail(fmt.Errorf("Section %s already exists in %s, use --force to replace its values", creds.section, creds.edgerc))
+			}
+		}
+	}
+
+	// Optional keys are only prompted for when no value was given as a flag.
+	interactive := true
+	for _, value := range values {
+		if *value != "" {
+			interactive = false
+		}
+	}
+
+	in := bufio.NewReader(c.stdin)
+	set := map[string]string{}
+	for _, key := range edgeRcKeys {
+		value := *values[key.name]
+		for {
+			if value == "" && (key.required || interactive) {
+				var err error
+				if value, err = c.prompt(in, key.name, key.required); err != nil {
+					return c.fail(err)
+				}
+			}
+			if value == "" && !key.required {
+				break
+			}
+
+			normalized, err := validateEdgeRcValue(key.name, value)
+			if err == nil {
+				value = normalized
+				break
+			}
+			if *values[key.name] != "" {
+				return c.fail(err)
+			}
+			fmt.Fprintf(c.stderr, "%s\n", err)
+			value = ""
+		}
+		set[key.name] = value
+	}
+
+	// The section is written at once, so that it is never left half written.
+	config := edgegrid.Config{
+		Host:         set["host"],
+		ClientToken:  set["client_token"],
+		ClientSecret: set["client_secret"],
+		AccessToken:  set["access_token"],
+		AccountKey:   set["account_key"],
+	}
+	if set["max_body"] != "" {
+		config.MaxBody, _ = strconv.Atoi(set["max_body"])
+	}
+	if set["headers_to_sign"] != "" {
+		for _, header := range strings.Split(set["headers_to_sign"], ",") {
+			config.HeaderToSign = append(config.HeaderToSign, strings.TrimSpace(header))
+		}
+	}
+
+	save := edgegrid.SaveEdgeRc
+	if force {
+		save = edgegrid.ReplaceEdgeRc
+	}
+	if err := save(creds.edgerc, creds.section, config); err != nil {
+		return c.fail(err)
+	}
+	fmt.Fprintf(c.stdout, "Saved section %s of %s\n", creds.section, creds.edgerc)
+	return 0
+}
+
+// prompt asks for the value of key on stdout and reads it from in.
+func (c *cli) prompt(in *bufio.Reader, key string, required bool) (string, error) {
+	if required {
+		fmt.Fprintf(c.stdout, "%s: ", key)
+	} else {
+		fmt.Fprintf(c.stdout, "%s (optional): ", key)
+	}
+	line, err := in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		if err != io.EOF {
+			return "", err
+		}
+		if required {
+			return "", fmt.Errorf("Missing value of %s", key)
+		}
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// configSet sets one key of a section.
+func (c *cli) configSet(args []string) int {
+	var creds credentialFlags
+	flags := c.newFlagSet("config set", "edgegrid config set [flags] KEY VALUE")
+	creds.register(flags)
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	key := strings.ToLower(strings.Replace(positional[0], "-", "_", -1))
+	value, err := validateEdgeRcValue(key, positional[1])
+	if err != nil {
+		return c.fail(err)
+	}
+	if err := edgegrid.SetEdgeRcValue(creds.edgerc, creds.section, key, value); err != nil {
+		return c.fail(err)
+	}
+	return 0
+}
+
+// configRemove removes a section.
+func (c *cli) configRemove(args []string) int {
+	var creds credentialFlags
+	flags := c.newFlagSet("config remove", "edgegrid config remove [flags]")
+	creds.register(flags)
+	if _, err := parseInterspersed(flags, args); err != nil {
+		return 2
+	}
+
+	if err := edgegrid.RemoveEdgeRc(creds.edgerc, creds.section); err != nil {
+		return c.fail(err)
+	}
+	return 0
+}
+
+// configTest makes a signed request with the credentials and reports DNS problems, clock skew
+// and authentication failures distinctly.
+func (c *cli) configTest(args []string) int {
+	var (
+		creds    credentialFlags
+		endpoint string
+		timeout  time.Duration
+	)
+	flags := c.newFlagSet("config test", "edgegrid config test [flags]")
+	creds.register(flags)
+	flags.StringVar(&endpoint, "endpoint", defaultTestEndpoint, "`path` to request")
+	flags.DurationVar(&timeout, "timeout", 10*time.Second, "request timeout")
+	if _, err := parseInterspersed(flags, args); err != nil {
+		return 2
+	}
+
+	config, err := creds.config()
+	if err != nil {
+		return c.fail(err)
+	}
+	client, err := c.client(config, timeout)
+	if err != nil {
+		return c.fail(err)
+	}
+
+	result := c.probe(client, endpoint)
+	for _, line := range result.report() {
+		fmt.Fprintln(c.stdout, line)
+	}
+	if !result.ok() {
+		return 1
+	}
+	return 0
+}
+
+// probeResult is the outcome of a signed test request.
+type probeResult struct {
+	host     string
+	endpoint string
+	elapsed  time.Duration
+	err      error
+	status   int
+	apiErr   *edgegrid.Error
+
+	// skew is the server clock minus the local clock, if the response had a Date header.
+	skew    time.Duration
+	hasDate bool
+}
+
+// probe sends a signed GET request for endpoint.
+func (c *cli) probe(client *edgegrid.Client, endpoint string) *probeResult {
+	result := &probeResult{host: client.BaseURL.Host, endpoint: endpoint}
+
+	req, err := client.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	req.Header.Set("Accept", "application/json")
+
+	start := time.Now()
+	res, err := client.Do(req)
+	result.elapsed = time.Since(start)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	body, _ := res.Bytes()
+	result.status = res.StatusCode
+
+	if date, err := http.ParseTime(res.Header.Get("Date")); err == nil {
+		result.hasDate = true
+		result.skew = date.Sub(start.Add(result.elapsed / 2)).Truncate(time.Second)
+	}
+	if res.StatusCode >= 300 {
+		result.apiErr = edgegrid.NewError(res.StatusCode, res.Header, body)
+	}
+	return result
+}
+
+// maxSkew is the clock difference beyond which Akamai rejects request timestamps.
+const maxSkew = 30 * time.Second
+
+func (r *probeResult) ok() bool {
+	return r.err == nil && r.apiErr == nil
+}
+
+// clockSkew reports whether the request failed because of its timestamp.
+func (r *probeResult) clockSkew() bool {
+	if r.apiErr == nil || r.apiErr.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+	return strings.Contains(strings.ToLower(r.apiErr.Title+" "+r.apiErr.Detail), "timestamp")
+}
+
+// report describes the result, one line per finding.
+func (r *probeResult) report() []string {
+	var lines []string
+	var dnsErr *net.DNSError
+
+	switch {
+	case errors.As(r.err, &dnsErr):
+		lines = append(lines, fmt.Sprintf("DNS failure: unable to resolve %s (%s). Check the host of the credentials.", r.host, dnsErr.Err))
+	case r.err != nil:
+		lines = append(lines, fmt.Sprintf("Connection failure: %s", r.err))
+	case r.clockSkew():
+		lines = append(lines, fmt.Sprintf("Clock skew: %s rejected the request timestamp (%s). Synchronize the local clock, e.g. with NTP.", r.host, r.apiErr.Detail))
+	case r.apiErr != nil && r.status == http.StatusUnauthorized:
+		lines = append(lines, fmt.Sprintf("Authentication failure: %s. Check the client_token, client_secret and access_token.", r.apiErr))
+	case r.apiErr != nil && r.status == http.StatusForbidden:
+		lines = append(lines, fmt.Sprintf("Authorization failure: the credentials are valid but not allowed to call %s: %s.", r.endpoint, r.apiErr))
+	case r.apiErr != nil:
+		lines = append(lines, fmt.Sprintf("Request failure: GET %s: %s.", r.endpoint, r.apiErr))
+	default:
+		lines = append(lines, fmt.Sprintf("OK: GET %s returned %d in %s.", r.endpoint, r.status, r.elapsed.Round(time.Millisecond)))
+	}
+
+	if r.hasDate && (r.skew > maxSkew || r.skew < -maxSkew) {
+		direction := "behind"
+		skew := r.skew
+		if skew < 0 {
+			direction, skew = "ahead of", -skew
+		}
+		lines = append(lines, fmt.Sprintf("Clock skew: the local clock is %s %s the server.", skew, direction))
+	}
+	return lines
+}
+
+// validateEdgeRcValue checks value of the .edgerc key and returns it normalized.
+func validateEdgeRcValue(key, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch key {
+	case "host":
+		host := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(value, "https://"), "http://"), "/")
+		if host == "" || strings.ContainsAny(host, "/ ") {
+			return "", fmt.Errorf("Invalid host %q, expected a host name such as akab-xxx.luna.akamaiapis.net", value)
+		}
+		return host, nil
+	case "client_token", "access_token":
+		if !strings.HasPrefix(value, "akab-") || strings.ContainsAny(value, " \t") {
+			return "", fmt.Errorf("Invalid %s %q, expected a token starting with akab-", key, value)
+		}
+		return value, nil
+	case "client_secret":
+		if _, err := base64.StdEncoding.DecodeString(value); err != nil || value == "" {
+			return "", fmt.Errorf("Invalid client_secret, expected a base64 value")
+		}
+		return value, nil
+	case "max_body":
+		if n, err := strconv.Atoi(value); err != nil || n <= 0 {
+			return "", fmt.Errorf("Invalid max_body %q, expected a positive number of bytes", value)
+		}
+		return value, nil
+	case "account_key", "headers_to_sign":
+		if value == "" {
+			return "", fmt.Errorf("Missing value of %s", key)
+		}
+		return value, nil
+	}
+	return "", fmt.Errorf("Unknown key %q", key)
+}
+
+// redact hides all but the last four characters of a secret.
+func redact(secret string) string {
+	if len(secret) <= 4 {
+		return strings.Repeat("*", len(secret))
+	}
+	return strings.Repeat("*", len(secret)-4) + secret[len(secret)-4:]
+}
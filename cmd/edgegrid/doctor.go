@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"gopkg.in/mattes/go-expand-tilde.v1"
+)
+
+// proxyVariables are the environment variables net/http reads the proxy configuration from.
+var proxyVariables = []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy", "NO_PROXY", "no_proxy"}
+
+// doctor collects the findings of the doctor command.
+type doctor struct {
+	printer *printer
+	failed  bool
+}
+
+func (d *doctor) heading(title string) {
+	fmt.Fprintf(d.printer.w, "%s\n", d.printer.paint(colorBlue, title))
+}
+
+func (d *doctor) ok(format string, args ...interface{}) {
+	fmt.Fprintf(d.printer.w, "  %s %s\n", d.printer.paint(colorGreen, "ok  "), fmt.Sprintf(format, args...))
+}
+
+func (d *doctor) info(format string, args ...interface{}) {
+	fmt.Fprintf(d.printer.w, "  %s %s\n", "    ", fmt.Sprintf(format, args...))
+}
+
+func (d *doctor) warn(format string, args ...interface{}) {
+	fmt.Fprintf(d.printer.w, "  %s %s\n", d.printer.paint(colorYellow, "warn"), fmt.Sprintf(format, args...))
+}
+
+func (d *doctor) fail(format string, args ...interface{}) {
+	d.failed = true
+	fmt.Fprintf(d.printer.w, "  %s %s\n", d.printer.paint(colorRed, "fail"), fmt.Sprintf(format, args...))
+}
+
+// doctor runs the doctor command, diagnosing the usual causes of failing EdgeGrid requests.
+func (c *cli) doctor(args []string) int {
+	var (
+		creds    credentialFlags
+		endpoint string
+		offline  bool
+		color    string
+		timeout  time.Duration
+	)
+	flags := c.newFlagSet("doctor", "edgegrid doctor [flags]")
+	creds.register(flags)
+	flags.StringVar(&endpoint, "endpoint", defaultTestEndpoint, "`path` requested to compare clocks")
+	flags.BoolVar(&offline, "offline", false, "skip the request to the server")
+	flags.StringVar(&color, "color", "auto", "colorize output: auto, always or never")
+	flags.DurationVar(&timeout, "timeout", 10*time.Second, "request timeout")
+	if _, err := parseInterspersed(flags, args); err != nil {
+		return 2
+	}
+	d := &doctor{printer: newPrinter(c.stdout, color)}
+
+	d.heading("Credentials source")
+	source := edgegrid.InitSource(creds.edgerc, creds.section)
+	c.diagnoseSource(d, source)
+
+	d.heading("Credentials file")
+	c.diagnoseFile(d, source)
+
+	d.heading("Configuration")
+	config, err := source.Config, source.Err
+	if err != nil {
+		d.fail("Unable to load credentials: %s", err)
+	} else {
+		diagnoseConfig(d, config)
+	}
+
+	d.heading("Proxy")
+	diagnoseProxy(d, config)
+
+	if !offline && err == nil {
+		d.heading("Server")
+		c.diagnoseServer(d, config, endpoint, timeout)
+	}
+
+	if d.failed {
+		return 1
+	}
+	return 0
+}
+
+// diagnoseSource reports where Init loads the credentials from and which settings it ignores.
+func (c *cli) diagnoseSource(d *doctor, source edgegrid.ConfigSource) {
+	if source.EnvPrefix == "" {
+		d.ok("Section %s of %s: %s", source.Section, source.Path, source.Reason)
+	} else {
+		d.ok("Environment variables %s*: %s", source.EnvPrefix, source.Reason)
+		if sections, err := edgegrid.EdgeRcSections(source.Path); err == nil && contains(sections, source.Section) {
+			d.warn("Section %s of %s is ignored because the environment takes precedence, unset %sHOST to use it", source.Section, source.Path, source.EnvPrefix)
+		}
+	}
+
+	var names []string
+	for _, env := range os.Environ() {
+		name := strings.SplitN(env, "=", 2)[0]
+		if strings.HasPrefix(name, "AKAMAI_") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if len(names) > 0 {
+		d.info("Set AKAMAI_ variables: %s", strings.Join(names, ", "))
+	}
+}
+
+// diagnoseFile checks that the .edgerc file exists, is private and has the requested section.
+func (c *cli) diagnoseFile(d *doctor, source edgegrid.ConfigSource) {
+	path, err := tilde.Expand(source.Path)
+	if err != nil {
+		d.fail("Unable to expand %s: %s", source.Path, err)
+		return
+	}
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		if source.EnvPrefix == "" {
+			d.fail("%s does not exist, create it with edgegrid config add", path)
+		} else {
+			d.info("%s does not exist", path)
+		}
+		return
+	}
+	if err != nil {
+		d.fail("Unable to read %s: %s", path, err)
+		return
+	}
+
+	if mode := info.Mode().Perm(); mode&0077 != 0 {
+		d.warn("%s has mode %04o and is readable by other users, run chmod 600 %s", path, mode, path)
+	} else {
+		d.ok("%s has mode %04o", path, mode)
+	}
+
+	sections, err := edgegrid.EdgeRcSections(path)
+	if err != nil {
+		d.fail("%s", err)
+		return
+	}
+	if contains(sections, source.Section) {
+		d.ok("Section %s exists", source.Section)
+		return
+	}
+	report := d.fail
+	if source.EnvPrefix != "" {
+		report = d.info
+	}
+	for _, section := range sections {
+		if strings.EqualFold(section, source.Section) {
+			report("Section %s does not exist, sections are matched in lower case but the file has %s", source.Section, section)
+			return
+		}
+	}
+	report("Section %s does not exist, the file has %s", source.Section, strings.Join(sections, ", "))
+}
+
+// diagnoseConfig validates the loaded credentials.
+func diagnoseConfig(d *doctor, config edgegrid.Config) {
+	values := []struct{ key, value string }{
+		{"host", config.Host},
+		{"client_token", config.ClientToken},
+		{"client_secret", config.ClientSecret},
+		{"access_token", config.AccessToken},
+	}
+	for _, v := range values {
+		normalized, err := validateEdgeRcValue(v.key, v.value)
+		switch {
+		case err != nil:
+			d.fail("%s", err)
+		case normalized != v.value && v.key == "host":
+			d.fail("Invalid host %q, the host must not include a scheme or path, use %s", v.value, normalized)
+		case normalized != v.value:
+			d.fail("The %s has leading or trailing white space", v.key)
+		case v.key == "client_secret":
+			d.ok("client_secret %s", redact(v.value))
+		default:
+			d.ok("%s %s", v.key, v.value)
+		}
+	}
+	if !strings.HasSuffix(strings.TrimSuffix(config.Host, "/"), ".akamaiapis.net") {
+		d.warn("Host %s is not an akamaiapis.net API host", config.Host)
+	}
+	if config.MaxBody != 131072 {
+		d.warn("max_body is %d, Akamai APIs sign the first 131072 bytes of request bodies", config.MaxBody)
+	}
+	if config.AccountKey != "" {
+		d.info("Requests switch to account %s", config.AccountKey)
+	}
+}
+
+// diagnoseProxy prints the proxy settings and the proxy requests to the API host go through.
+func diagnoseProxy(d *doctor, config edgegrid.Config) {
+	set := false
+	for _, name := range proxyVariables {
+		if value, ok := os.LookupEnv(name); ok {
+			set = true
+			d.info("%s=%s", name, redactProxy(value))
+		}
+	}
+	if !set {
+		d.info("No proxy variables are set")
+	}
+	if config.Host == "" {
+		return
+	}
+
+	req, err := http.NewRequest("GET", "https://"+config.Host, nil)
+	if err != nil {
+		return
+	}
+	proxy, err := http.ProxyFromEnvironment(req)
+	switch {
+	case err != nil:
+		d.fail("Invalid proxy setting: %s", err)
+	case proxy != nil:
+		d.ok("Requests to %s go through %s", req.URL.Host, proxy.Redacted())
+	default:
+		d.ok("Requests to %s connect directly", req.URL.Host)
+	}
+}
+
+// diagnoseServer makes a signed request and compares the clocks.
+func (c *cli) diagnoseServer(d *doctor, config edgegrid.Config, endpoint string, timeout time.Duration) {
+	client, err := c.client(config, timeout)
+	if err != nil {
+		d.fail("%s", err)
+		return
+	}
+	result := c.probe(client, endpoint)
+	for _, line := range result.report() {
+		if strings.HasPrefix(line, "OK: ") {
+			d.ok("%s", strings.TrimPrefix(line, "OK: "))
+		} else {
+			d.fail("%s", line)
+		}
+	}
+	if result.hasDate && result.skew <= maxSkew && result.skew >= -maxSkew && !result.clockSkew() {
+		d.ok("The local clock is within %s of the server", maxSkew)
+	} else if !result.hasDate && result.err == nil {
+		d.warn("The response had no Date header to compare the clocks")
+	}
+}
+
+// redactProxy hides the password of a proxy URL.
+func redactProxy(value string) string {
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		return u.Redacted()
+	}
+	return value
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
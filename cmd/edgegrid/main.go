@@ -0,0 +1,155 @@
+// Command edgegrid sends signed requests to Akamai APIs from the command line.
+//
+// Usage:
+//
+//	edgegrid [--edgerc path] [--section name] [METHOD] URL [ITEM...]
+//
+// URL is a path relative to the host of the credentials, e.g. /diagnostic-tools/v1/locations,
+// or a full URL. METHOD defaults to GET, or POST when the request has data fields. Items use
+// the httpie syntax:
+//
+//	key==value    query parameter
+//	Header:value  request header
+//	field=value   JSON string field of the request body
+//	field:=json   raw JSON field of the request body, e.g. count:=3 or tags:='["a","b"]'
+//
+// Credentials are resolved with edgegrid.Init: AKAMAI_* environment variables first, then the
+// section of the .edgerc file. JSON responses are pretty-printed and colorized on terminals.
+// The exit status is 0 for 2xx responses, 3, 4 or 5 for 3xx, 4xx or 5xx responses and 1 for
+// other errors.
+//
+// Subcommands:
+//
+//	edgegrid config list|show|add|set|remove|test [flags]
+//	                                    manage and test the sections of the .edgerc file
+//	edgegrid doctor [flags]             diagnose credentials, clock and proxy settings
+//	edgegrid proxy [flags]              sign the requests of tools that cannot, as a local reverse proxy
+//	edgegrid sign [flags] METHOD URL    print the Authorization header or a curl command line
+//	edgegrid verify [flags] CAPTURE     recompute the signature of a captured request
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// cli holds the streams and HTTP client of a command run, so that tests can replace them.
+type cli struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	// httpClient sends requests; nil uses http.DefaultClient.
+	httpClient *http.Client
+}
+
+func main() {
+	c := &cli{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	os.Exit(c.run(os.Args[1:]))
+}
+
+// commands are the subcommands by name. Other arguments describe a request.
+var commands = map[string]func(c *cli, args []string) int{
+	"config": (*cli).config,
+	"doctor": (*cli).doctor,
+	"proxy":  (*cli).proxy,
+	"sign":   (*cli).sign,
+	"verify": (*cli).verify,
+}
+
+// run runs the command line args and returns the exit status.
+func (c *cli) run(args []string) int {
+	if len(args) > 0 {
+		if command, ok := commands[args[0]]; ok {
+			return command(c, args[1:])
+		}
+	}
+	return c.request(args)
+}
+
+// credentialFlags are the flags selecting credentials, shared by every command.
+type credentialFlags struct {
+	edgerc  string
+	section string
+}
+
+func (f *credentialFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&f.edgerc, "edgerc", "~/.edgerc", "path of the `.edgerc` file")
+	flags.StringVar(&f.section, "section", "default", "`section` of the .edgerc file")
+}
+
+// config resolves the credentials like edgegrid.Init.
+func (f *credentialFlags) config() (edgegrid.Config, error) {
+	config, err := edgegrid.Init(f.edgerc, f.section)
+	if err != nil {
+		return config, fmt.Errorf("Unable to load credentials from section %q of %s: %s", f.section, f.edgerc, err)
+	}
+	return config, nil
+}
+
+// client creates a client for config with timeout.
+func (c *cli) client(config edgegrid.Config, timeout time.Duration) (*edgegrid.Client, error) {
+	client, err := edgegrid.New(c.httpClient, config)
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = timeout
+	return client, nil
+}
+
+// fail prints err and returns the exit status for errors.
+func (c *cli) fail(err error) int {
+	fmt.Fprintf(c.stderr, "edgegrid: %s\n", err)
+	return 1
+}
+
+// newFlagSet creates a flag set for command that reports errors to stderr.
+func (c *cli) newFlagSet(command, usage string) *flag.FlagSet {
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(c.stderr, "Usage: %s\n\nFlags:\n", usage)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseInterspersed parses flags given before, between or after the positional arguments,
+// which it returns.
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := flags.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		// Everything after a "--" terminator is positional.
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// stringList is a flag that can be repeated.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ", ")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
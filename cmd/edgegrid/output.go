@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// ANSI colors of the output.
+const (
+	colorReset  = "\x1b[0m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+	colorBlue   = "\x1b[34m"
+	colorPurple = "\x1b[35m"
+	colorCyan   = "\x1b[36m"
+)
+
+// printer writes responses, colorized if enabled.
+type printer struct {
+	w     io.Writer
+	color bool
+}
+
+// newPrinter creates a printer for w. mode is "always", "never" or "auto", which colorizes
+// terminals unless the NO_COLOR environment variable is set.
+func newPrinter(w io.Writer, mode string) *printer {
+	color := false
+	switch mode {
+	case "always":
+		color = true
+	case "auto":
+		_, noColor := os.LookupEnv("NO_COLOR")
+		color = !noColor && isTerminal(w)
+	}
+	return &printer{w: w, color: color}
+}
+
+func (p *printer) paint(color, s string) string {
+	if !p.color || s == "" {
+		return s
+	}
+	return color + s + colorReset
+}
+
+// status writes a status line colored by its class.
+func (p *printer) status(proto, status string, code int) {
+	color := colorGreen
+	switch {
+	case code >= 400:
+		color = colorRed
+	case code >= 300:
+		color = colorYellow
+	}
+	fmt.Fprintf(p.w, "%s %s\n", p.paint(colorBlue, proto), p.paint(color, status))
+}
+
+// headers writes header sorted by name.
+func (p *printer) headers(header http.Header) {
+	for _, name := range sortedKeys(header) {
+		for _, value := range header[name] {
+			fmt.Fprintf(p.w, "%s: %s\n", p.paint(colorCyan, name), value)
+		}
+	}
+}
+
+// body writes a response body, indenting and colorizing JSON.
+func (p *printer) body(contentType string, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+
+	isJSON := strings.Contains(contentType, "json") || contentType == ""
+	var indented bytes.Buffer
+	if !isJSON || json.Indent(&indented, data, "", "  ") != nil {
+		p.w.Write(data)
+		if data[len(data)-1] != '\n' {
+			fmt.Fprintln(p.w)
+		}
+		return
+	}
+
+	if p.color {
+		p.w.Write(colorizeJSON(indented.Bytes()))
+	} else {
+		p.w.Write(indented.Bytes())
+	}
+	fmt.Fprintln(p.w)
+}
+
+// colorizeJSON adds colors to valid, indented JSON: keys blue, strings green, numbers cyan and
+// true, false and null purple.
+func colorizeJSON(data []byte) []byte {
+	var b bytes.Buffer
+	for i := 0; i < len(data); {
+		switch ch := data[i]; {
+		case ch == '"':
+			end := i + 1
+			for end < len(data) && data[end] != '"' {
+				if data[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end++
+
+			// A string followed by a colon is a key.
+			next := end
+			for next < len(data) && (data[next] == ' ' || data[next] == '\n') {
+				next++
+			}
+			color := colorGreen
+			if next < len(data) && data[next] == ':' {
+				color = colorBlue
+			}
+			b.WriteString(color)
+			b.Write(data[i:end])
+			b.WriteString(colorReset)
+			i = end
+		case ch == '-' || (ch >= '0' && ch <= '9'):
+			end := i
+			for end < len(data) && strings.IndexByte("+-.eE0123456789", data[end]) >= 0 {
+				end++
+			}
+			b.WriteString(colorCyan)
+			b.Write(data[i:end])
+			b.WriteString(colorReset)
+			i = end
+		case ch == 't' || ch == 'f' || ch == 'n':
+			end := i
+			for end < len(data) && data[end] >= 'a' && data[end] <= 'z' {
+				end++
+			}
+			b.WriteString(colorPurple)
+			b.Write(data[i:end])
+			b.WriteString(colorReset)
+			i = end
+		default:
+			b.WriteByte(ch)
+			i++
+		}
+	}
+	return b.Bytes()
+}
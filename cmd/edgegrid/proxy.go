@@ -0,0 +1,288 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/signal"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// proxy runs the proxy command, a local reverse proxy signing plain HTTP requests for tools
+// that cannot sign them.
+func (c *cli) proxy(args []string) int {
+	var (
+		creds     credentialFlags
+		listen    string
+		allow     stringList
+		accessLog string
+	)
+	flags := c.newFlagSet("proxy", "edgegrid proxy [flags]")
+	creds.register(flags)
+	flags.StringVar(&listen, "listen", "127.0.0.1:8080", "`address` to listen on")
+	flags.Var(&allow, "allow", "forward only requests matching `[METHODS] PATH`, e.g. \"GET,HEAD /papi/v1/*\" (repeatable)")
+	flags.StringVar(&accessLog, "access-log", "-", "`path` of the access log, - for stderr")
+	if _, err := parseInterspersed(flags, args); err != nil {
+		return 2
+	}
+
+	config, err := creds.config()
+	if err != nil {
+		return c.fail(err)
+	}
+	var rules []allowRule
+	for _, value := range allow {
+		rule, err := parseAllowRule(value)
+		if err != nil {
+			return c.fail(err)
+		}
+		rules = append(rules, rule)
+	}
+
+	logWriter := c.stderr
+	if accessLog != "-" {
+		file, err := os.OpenFile(accessLog, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			return c.fail(fmt.Errorf("Unable to open access log: %s", err))
+		}
+		defer file.Close()
+		logWriter = file
+	}
+
+	var transport http.RoundTripper
+	if c.httpClient != nil {
+		transport = c.httpClient.Transport
+	}
+
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return c.fail(err)
+	}
+	fmt.Fprintf(c.stderr, "Signing requests to %s, listening on http://%s\n", config.Host, listener.Addr())
+	if len(rules) == 0 {
+		fmt.Fprintln(c.stderr, "edgegrid: no --allow rules, every request is forwarded")
+	}
+	if ip := listener.Addr().(*net.TCPAddr).IP; !ip.IsLoopback() {
+		fmt.Fprintf(c.stderr, "edgegrid: %s is reachable from other hosts, which can then call the API with these credentials\n", listener.Addr())
+	}
+
+	server := &http.Server{
+		Handler:           newSigningProxy(config, transport, rules, logWriter),
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdown)
+	}()
+
+	if err := server.Serve(listener); err != http.ErrServerClosed {
+		return c.fail(err)
+	}
+	return 0
+}
+
+// allowRule permits requests with one of methods, any method when empty, and a path equal to
+// path or, when path ends with "/" or "*", starting with it.
+type allowRule struct {
+	methods []string
+	path    string
+	prefix  bool
+}
+
+// parseAllowRule parses rules such as "/papi/v1/*", "GET /papi/v1/groups" or "GET,HEAD /papi/".
+func parseAllowRule(value string) (allowRule, error) {
+	var rule allowRule
+	fields := strings.Fields(value)
+	switch len(fields) {
+	case 1:
+		rule.path = fields[0]
+	case 2:
+		rule.methods = strings.Split(strings.ToUpper(fields[0]), ",")
+		rule.path = fields[1]
+	default:
+		return rule, fmt.Errorf("Invalid allow rule %q, expected [METHODS] PATH", value)
+	}
+	if !strings.HasPrefix(rule.path, "/") {
+		return rule, fmt.Errorf("Invalid allow rule %q, the path must start with /", value)
+	}
+	if strings.HasSuffix(rule.path, "*") || strings.HasSuffix(rule.path, "/") {
+		rule.path = strings.TrimSuffix(rule.path, "*")
+		rule.prefix = true
+	}
+	return rule, nil
+}
+
+func (r allowRule) matches(req *http.Request) bool {
+	if len(r.methods) > 0 && !contains(r.methods, req.Method) {
+		return false
+	}
+	if r.prefix {
+		return strings.HasPrefix(req.URL.Path, r.path)
+	}
+	return req.URL.Path == r.path
+}
+
+// signingProxy forwards requests to the host of its credentials, signing them on the way.
+type signingProxy struct {
+	allow     []allowRule
+	proxy     *httputil.ReverseProxy
+	accessLog *log.Logger
+}
+
+// newSigningProxy creates a signingProxy sending requests with transport, http.DefaultTransport
+// when nil, and logging each request to accessLog.
+func newSigningProxy(config edgegrid.Config, transport http.RoundTripper, allow []allowRule, accessLog io.Writer) *signingProxy {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	host := strings.TrimSuffix(config.Host, "/")
+	p := &signingProxy{
+		allow:     allow,
+		accessLog: log.New(accessLog, "", 0),
+	}
+	p.proxy = &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.Out.URL.Scheme = "https"
+			r.Out.URL.Host = host
+			r.Out.Host = ""
+			r.Out.Header.Del("Authorization")
+			if config.AccountKey != "" && r.Out.URL.Query().Get("accountSwitchKey") == "" {
+				query := r.Out.URL.Query()
+				query.Set("accountSwitchKey", config.AccountKey)
+				r.Out.URL.RawQuery = query.Encode()
+			}
+		},
+		Transport:     &signingTransport{config: config, next: transport},
+		FlushInterval: -1,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			writeProblem(w, http.StatusBadGateway, "Bad Gateway", err.Error())
+		},
+	}
+	return p
+}
+
+func (p *signingProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+
+	if p.allowed(r) {
+		p.proxy.ServeHTTP(rec, r)
+	} else {
+		writeProblem(rec, http.StatusForbidden, "Forbidden", fmt.Sprintf("%s %s is not allowed by the proxy", r.Method, r.URL.Path))
+	}
+
+	p.accessLog.Printf("%s %s %q %d %d %s", start.UTC().Format(time.RFC3339), r.RemoteAddr,
+		r.Method+" "+r.URL.RequestURI(), rec.status, rec.bytes, time.Since(start).Round(time.Millisecond))
+}
+
+func (p *signingProxy) allowed(r *http.Request) bool {
+	if len(p.allow) == 0 {
+		return true
+	}
+	// Rules match the path as sent, so paths the API would resolve elsewhere, e.g.
+	// /papi/v1/../../identity-management, are rejected.
+	if !cleanPath(r.URL) {
+		return false
+	}
+	for _, rule := range p.allow {
+		if rule.matches(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanPath reports whether the path of u has no dot segments nor repeated slashes, decoded or
+// percent-encoded in its raw form.
+func cleanPath(u *url.URL) bool {
+	clean := path.Clean(u.Path)
+	if strings.HasSuffix(u.Path, "/") && clean != "/" {
+		clean += "/"
+	}
+	if clean != u.Path {
+		return false
+	}
+	for _, segment := range strings.Split(u.RawPath, "/") {
+		if segment, err := url.PathUnescape(segment); err != nil || segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// signingTransport signs requests with config before sending them with next.
+type signingTransport struct {
+	config edgegrid.Config
+	next   http.RoundTripper
+}
+
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	nonce := edgegrid.CreateNonce()
+	if nonce == "" {
+		return nil, errors.New("Unable to generate a nonce")
+	}
+	req = req.Clone(req.Context())
+
+	// Only POST bodies are signed, other bodies are streamed without being read for signing.
+	body := req.Body
+	if req.Method != "POST" {
+		req.Body = nil
+	}
+	t.config.SignRequest(req, edgegrid.MakeEdgeTimeStamp(), nonce)
+	if req.Method != "POST" {
+		req.Body = body
+	}
+	return t.next.RoundTrip(req)
+}
+
+// responseRecorder records the status and size of a response for the access log.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap lets http.ResponseController flush streamed responses.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// writeProblem writes an application/problem+json response.
+func writeProblem(w http.ResponseWriter, status int, title, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"type":   "about:blank",
+		"title":  title,
+		"status": status,
+		"detail": detail,
+	})
+}
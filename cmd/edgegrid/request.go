@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// methods are the HTTP methods recognized as the first argument of a request.
+var methods = map[string]bool{
+	"GET": true, "HEAD": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "OPTIONS": true,
+}
+
+// requestSpec is a request described by command line items.
+type requestSpec struct {
+	method string
+	url    string
+	query  url.Values
+	header http.Header
+
+	// fields are the JSON body fields in command line order.
+	fields []field
+}
+
+type field struct {
+	name  string
+	value json.RawMessage
+}
+
+// request runs the default command, sending one signed request.
+func (c *cli) request(args []string) int {
+	var (
+		creds    credentialFlags
+		verbose  bool
+		color    string
+		bodyFile string
+		timeout  time.Duration
+	)
+	flags := c.newFlagSet("edgegrid", "edgegrid [flags] [METHOD] URL [key==value] [Header:value] [field=value] [field:=json]")
+	creds.register(flags)
+	flags.BoolVar(&verbose, "v", false, "print the response status line and headers")
+	flags.StringVar(&color, "color", "auto", "colorize output: auto, always or never")
+	flags.StringVar(&bodyFile, "body", "", "send the contents of `file` as the request body, - for standard input")
+	flags.DurationVar(&timeout, "timeout", 30*time.Second, "request timeout")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	spec, err := parseRequest(flags.Args())
+	if err != nil {
+		fmt.Fprintf(c.stderr, "edgegrid: %s\n", err)
+		flags.Usage()
+		return 2
+	}
+
+	var body io.Reader
+	switch {
+	case bodyFile != "" && len(spec.fields) > 0:
+		return c.fail(errors.New("Unable to combine --body with data fields"))
+	case bodyFile == "-":
+		body = c.stdin
+	case bodyFile != "":
+		data, err := ioutil.ReadFile(bodyFile)
+		if err != nil {
+			return c.fail(err)
+		}
+		body = bytes.NewReader(data)
+	case len(spec.fields) > 0:
+		body = bytes.NewReader(spec.body())
+		if spec.header.Get("Content-Type") == "" {
+			spec.header.Set("Content-Type", "application/json")
+		}
+	}
+
+	config, err := creds.config()
+	if err != nil {
+		return c.fail(err)
+	}
+	client, err := c.client(config, timeout)
+	if err != nil {
+		return c.fail(err)
+	}
+
+	req, err := client.NewRequest(spec.method, spec.target(), body)
+	if err != nil {
+		return c.fail(err)
+	}
+	for name, values := range spec.header {
+		req.Header[name] = values
+	}
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json, */*")
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return c.fail(err)
+	}
+	data, err := res.Bytes()
+	if err != nil {
+		return c.fail(err)
+	}
+
+	out := newPrinter(c.stdout, color)
+	if verbose {
+		out.status(res.Proto, res.Status, res.StatusCode)
+		out.headers(res.Header)
+		fmt.Fprintln(c.stdout)
+	}
+	out.body(res.Header.Get("Content-Type"), data)
+
+	if res.StatusCode >= 300 {
+		fmt.Fprintf(c.stderr, "edgegrid: HTTP %s\n", res.Status)
+		return res.StatusCode / 100
+	}
+	return 0
+}
+
+// parseRequest parses the positional arguments [METHOD] URL [ITEM...].
+func parseRequest(args []string) (*requestSpec, error) {
+	spec := &requestSpec{query: url.Values{}, header: http.Header{}}
+
+	if len(args) > 0 && methods[strings.ToUpper(args[0])] && len(args) > 1 {
+		spec.method = strings.ToUpper(args[0])
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return nil, errors.New("Missing URL")
+	}
+	spec.url = args[0]
+
+	for _, item := range args[1:] {
+		if err := spec.addItem(item); err != nil {
+			return nil, err
+		}
+	}
+
+	if spec.method == "" {
+		spec.method = "GET"
+		if len(spec.fields) > 0 {
+			spec.method = "POST"
+		}
+	}
+	return spec, nil
+}
+
+// separators of request items. At the same position the longest wins, so "==" is a query
+// parameter rather than a field with a value starting with "=".
+var separators = []string{"==", ":=", "=", ":"}
+
+// addItem adds a key==value, Header:value, field=value or field:=json item.
+func (s *requestSpec) addItem(item string) error {
+	at, sep := -1, ""
+	for _, candidate := range separators {
+		i := strings.Index(item, candidate)
+		if i > 0 && (at < 0 || i < at || (i == at && len(candidate) > len(sep))) {
+			at, sep = i, candidate
+		}
+	}
+	if at < 0 {
+		return fmt.Errorf("Invalid item %q, expected key==value, Header:value, field=value or field:=json", item)
+	}
+	name, value := item[:at], item[at+len(sep):]
+
+	switch sep {
+	case "==":
+		s.query.Add(name, value)
+	case ":":
+		s.header.Add(name, value)
+	case "=":
+		encoded, _ := json.Marshal(value)
+		s.fields = append(s.fields, field{name, encoded})
+	case ":=":
+		if !json.Valid([]byte(value)) {
+			return fmt.Errorf("Invalid JSON in item %q", item)
+		}
+		s.fields = append(s.fields, field{name, json.RawMessage(value)})
+	}
+	return nil
+}
+
+// body returns the JSON object of the data fields, keeping their order.
+func (s *requestSpec) body() []byte {
+	var b bytes.Buffer
+	b.WriteString("{")
+	for i, f := range s.fields {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		name, _ := json.Marshal(f.name)
+		b.Write(name)
+		b.WriteString(":")
+		b.Write(f.value)
+	}
+	b.WriteString("}")
+	return b.Bytes()
+}
+
+// target returns the URL with the query parameters added.
+func (s *requestSpec) target() string {
+	if len(s.query) == 0 {
+		return s.url
+	}
+	sep := "?"
+	if strings.Contains(s.url, "?") {
+		sep = "&"
+	}
+	return s.url + sep + s.query.Encode()
+}
+
+// isTerminal reports whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// sortedKeys returns the keys of header in order.
+func sortedKeys(header http.Header) []string {
+	var keys []string
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
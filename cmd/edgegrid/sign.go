@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// timestampLayout is the layout of EdgeGrid timestamps, which must be in UTC.
+const timestampLayout = "20060102T15:04:05-0700"
+
+// validTimestamp reports whether timestamp is an EdgeGrid timestamp, e.g. 20140321T19:34:21+0000.
+func validTimestamp(timestamp string) bool {
+	_, err := time.Parse(timestampLayout, timestamp)
+	return err == nil && strings.HasSuffix(timestamp, "+0000")
+}
+
+// sign runs the sign subcommand, printing the Authorization header of a request, or a curl
+// command line sending it, without sending the request.
+func (c *cli) sign(args []string) int {
+	var (
+		creds       credentialFlags
+		bodyFile    string
+		headers     stringList
+		signHeaders stringList
+		timestamp   string
+		nonce       string
+		curl        bool
+	)
+	flags := c.newFlagSet("sign", "edgegrid sign [flags] METHOD URL")
+	creds.register(flags)
+	flags.StringVar(&bodyFile, "body", "", "request body `file`, - for standard input")
+	flags.Var(&headers, "H", "request `header` as \"Name: value\", repeatable")
+	flags.Var(&signHeaders, "sign-header", "`name` of a header to sign in addition to the headers_to_sign of the credentials, repeatable")
+	flags.StringVar(&timestamp, "timestamp", "", "signing `timestamp` as 20060102T15:04:05+0000, defaults to now")
+	flags.StringVar(&nonce, "nonce", "", "signing `nonce`, defaults to a random UUID")
+	flags.BoolVar(&curl, "curl", false, "print a curl command line instead of the Authorization header")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) != 2 {
+		flags.Usage()
+		return 2
+	}
+	method, target := strings.ToUpper(positional[0]), positional[1]
+
+	if timestamp == "" {
+		timestamp = edgegrid.MakeEdgeTimeStamp()
+	} else if !validTimestamp(timestamp) {
+		return c.fail(fmt.Errorf("Invalid timestamp %q, expected the UTC format 20060102T15:04:05+0000", timestamp))
+	}
+	if nonce == "" {
+		if nonce = edgegrid.CreateNonce(); nonce == "" {
+			return c.fail(errors.New("Unable to generate a nonce"))
+		}
+	}
+
+	var body []byte
+	switch bodyFile {
+	case "":
+	case "-":
+		body, err = ioutil.ReadAll(c.stdin)
+	default:
+		body, err = ioutil.ReadFile(bodyFile)
+	}
+	if err != nil {
+		return c.fail(err)
+	}
+
+	config, err := creds.config()
+	if err != nil {
+		return c.fail(err)
+	}
+	config.HeaderToSign = append(config.HeaderToSign, signHeaders...)
+	client, err := c.client(config, 0)
+	if err != nil {
+		return c.fail(err)
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := client.NewRequest(method, target, reader)
+	if err != nil {
+		return c.fail(err)
+	}
+	for _, header := range headers {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok {
+			return c.fail(fmt.Errorf("Invalid header %q, expected \"Name: value\"", header))
+		}
+		req.Header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	req.Header.Del("User-Agent")
+
+	config.SignRequest(req, timestamp, nonce)
+
+	if !curl {
+		fmt.Fprintf(c.stdout, "Authorization: %s\n", req.Header.Get("Authorization"))
+		return 0
+	}
+	fmt.Fprintln(c.stdout, curlCommand(req, bodyFile, body))
+	return 0
+}
+
+// curlCommand returns a curl command line sending req with body, read from bodyFile unless it
+// is standard input.
+func curlCommand(req *http.Request, bodyFile string, body []byte) string {
+	args := []string{"curl"}
+	// curl sends --data-binary as a POST, so the signed method is always given with a body
+	if req.Method != "GET" || body != nil {
+		args = append(args, "-X", req.Method)
+	}
+
+	header := req.Header.Clone()
+	if body != nil && header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "application/json")
+	}
+	for _, name := range sortedKeys(header) {
+		for _, value := range header[name] {
+			args = append(args, "-H", shellQuote(name+": "+value))
+		}
+	}
+
+	switch {
+	case body == nil:
+	case bodyFile != "-":
+		args = append(args, "--data-binary", shellQuote("@"+bodyFile))
+	default:
+		args = append(args, "--data-binary", shellQuote(string(body)))
+	}
+	return strings.Join(append(args, shellQuote(req.URL.String())), " ")
+}
+
+// shellQuote quotes s for POSIX shells.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// componentNames name the fields of the data to sign, see edgegrid.Config.SigningComponents.
+var componentNames = []string{"method", "scheme", "host", "path+query", "headers", "content hash", "auth header"}
+
+// capture is a captured request with its buffered body.
+type capture struct {
+	req  *http.Request
+	body []byte
+}
+
+// request returns a copy of the captured request with a fresh body.
+func (c *capture) request() *http.Request {
+	req := c.req.Clone(c.req.Context())
+	req.Body = ioutil.NopCloser(bytes.NewReader(c.body))
+	return req
+}
+
+// verify runs the verify subcommand, recomputing the signature of a captured request.
+func (c *cli) verify(args []string) int {
+	var (
+		creds      credentialFlags
+		signedData string
+		entry      int
+		scheme     string
+		color      string
+	)
+	flags := c.newFlagSet("verify", "edgegrid verify [flags] CAPTURE\n\nCAPTURE is a raw HTTP request or a HAR file, - for standard input.")
+	creds.register(flags)
+	flags.StringVar(&signedData, "signed-data", "", "`file` holding the data the client signed, as logged by its debug output, to compare field by field")
+	flags.IntVar(&entry, "entry", -1, "`index` of the HAR entry, defaults to the first with an EdgeGrid signature")
+	flags.StringVar(&scheme, "scheme", "https", "`scheme` of raw requests")
+	flags.StringVar(&color, "color", "auto", "colorize output: auto, always or never")
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	data, err := c.readInput(positional[0])
+	if err != nil {
+		return c.fail(err)
+	}
+	captured, err := parseCapture(data, entry, scheme)
+	if err != nil {
+		return c.fail(err)
+	}
+
+	var signed []string
+	if signedData != "" {
+		data, err := c.readInput(signedData)
+		if err != nil {
+			return c.fail(err)
+		}
+		if signed, err = parseSignedData(string(data)); err != nil {
+			return c.fail(err)
+		}
+	}
+
+	config, err := creds.config()
+	if err != nil {
+		return c.fail(err)
+	}
+
+	authorization := captured.req.Header.Get("Authorization")
+	unsigned, signature, fields, err := splitAuthorization(authorization)
+	if err != nil {
+		return c.fail(err)
+	}
+
+	out := newPrinter(c.stdout, color)
+	fmt.Fprintf(c.stdout, "%s\n\n", authorization)
+
+	components := config.SigningComponents(captured.request(), unsigned)
+	fmt.Fprintln(c.stdout, "Data to sign")
+	for i, component := range components {
+		if signed != nil && signed[i] != component {
+			row(c.stdout, componentNames[i], out.paint(colorRed, visible(component)))
+			row(c.stdout, "  signed", out.paint(colorRed, visible(signed[i])))
+			continue
+		}
+		row(c.stdout, componentNames[i], visible(component))
+	}
+
+	expected := config.SignRequest(captured.request(), fields["timestamp"], fields["nonce"]).Header.Get("Authorization")
+	_, expectedSignature, _, _ := splitAuthorization(expected)
+	matches := expectedSignature == signature
+
+	signatureColor := colorGreen
+	if !matches {
+		signatureColor = colorRed
+	}
+	fmt.Fprintln(c.stdout, "\nSignature")
+	row(c.stdout, "captured", signature)
+	row(c.stdout, "expected", out.paint(signatureColor, expectedSignature))
+
+	problems := diagnose(config, captured, fields)
+	if len(problems) > 0 {
+		fmt.Fprintln(c.stdout)
+		for _, problem := range problems {
+			fmt.Fprintf(c.stdout, "%s %s\n", out.paint(colorYellow, "!"), problem)
+		}
+	}
+
+	fmt.Fprintln(c.stdout)
+	if !matches {
+		fmt.Fprintln(c.stdout, out.paint(colorRed, "The signature does not match."))
+		return 1
+	}
+	fmt.Fprintln(c.stdout, out.paint(colorGreen, "The signature matches."))
+	return 0
+}
+
+// diagnose returns the likely causes of a signature mismatch found in the captured request.
+func diagnose(config edgegrid.Config, captured *capture, fields map[string]string) []string {
+	var problems []string
+	req := captured.req
+
+	if fields["client_token"] != config.ClientToken {
+		problems = append(problems, fmt.Sprintf("client_token %s differs from the credentials (%s)", fields["client_token"], config.ClientToken))
+	}
+	if fields["access_token"] != config.AccessToken {
+		problems = append(problems, fmt.Sprintf("access_token %s differs from the credentials (%s)", fields["access_token"], config.AccessToken))
+	}
+	if !validTimestamp(fields["timestamp"]) {
+		problems = append(problems, fmt.Sprintf("timestamp %q is not in the UTC format 20060102T15:04:05+0000", fields["timestamp"]))
+	}
+
+	host := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(config.Host, "https://"), "http://"), "/")
+	if !strings.EqualFold(req.URL.Host, host) {
+		problems = append(problems, fmt.Sprintf("The request host %s differs from the credentials host %s", req.URL.Host, config.Host))
+	}
+
+	for _, name := range config.HeaderToSign {
+		if http.CanonicalHeaderKey(name) != name {
+			problems = append(problems, fmt.Sprintf("headers_to_sign entry %q is never signed, write it as %q", name, http.CanonicalHeaderKey(name)))
+		} else if req.Header.Get(name) == "" {
+			problems = append(problems, fmt.Sprintf("Header %s of headers_to_sign is not in the request", name))
+		}
+	}
+
+	switch {
+	case req.Method == "POST" && len(captured.body) > config.MaxBody:
+		problems = append(problems, fmt.Sprintf("Only the first %d of %d body bytes (max_body) are hashed", config.MaxBody, len(captured.body)))
+	case req.Method != "POST" && len(captured.body) > 0:
+		problems = append(problems, fmt.Sprintf("The body of %s requests is not signed", req.Method))
+	}
+	return problems
+}
+
+// readInput reads the file at path, or standard input for "-".
+func (c *cli) readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return ioutil.ReadAll(c.stdin)
+	}
+	return ioutil.ReadFile(path)
+}
+
+// parseCapture parses a raw HTTP request or a HAR file. entry selects the HAR entry; a negative
+// entry selects the first with an EdgeGrid signature. scheme is the scheme of raw requests.
+func parseCapture(data []byte, entry int, scheme string) (*capture, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return parseHAR(data, entry)
+	}
+
+	br := bufio.NewReader(bytes.NewReader(data))
+	req, err := http.ReadRequest(br)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse raw HTTP request: %s", err)
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read request body: %s", err)
+	}
+	if req.ContentLength <= 0 && len(req.TransferEncoding) == 0 {
+		// Without a length the body is the rest of the capture.
+		rest, _ := ioutil.ReadAll(br)
+		body = append(body, bytes.TrimRight(rest, "\r\n")...)
+	}
+
+	if req.URL.Host == "" {
+		req.URL.Host = req.Host
+	}
+	if req.URL.Scheme == "" {
+		req.URL.Scheme = scheme
+	}
+	req.RequestURI = ""
+	return &capture{req: req, body: body}, nil
+}
+
+type harRequest struct {
+	Method  string `json:"method"`
+	URL     string `json:"url"`
+	Headers []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"headers"`
+	PostData *struct {
+		Text string `json:"text"`
+	} `json:"postData"`
+}
+
+type harEntry struct {
+	Request *harRequest `json:"request"`
+}
+
+// parseHAR parses a HAR file or a single HAR entry.
+func parseHAR(data []byte, index int) (*capture, error) {
+	var har struct {
+		harEntry
+		Log struct {
+			Entries []harEntry `json:"entries"`
+		} `json:"log"`
+	}
+	if err := json.Unmarshal(data, &har); err != nil {
+		return nil, fmt.Errorf("Unable to parse HAR: %s", err)
+	}
+	entries := har.Log.Entries
+	if har.Request != nil {
+		entries = append(entries, har.harEntry)
+	}
+
+	var selected *harRequest
+	switch {
+	case index >= len(entries):
+		return nil, fmt.Errorf("HAR has %d entries, no entry %d", len(entries), index)
+	case index >= 0:
+		if selected = entries[index].Request; selected == nil {
+			return nil, fmt.Errorf("HAR entry %d has no request", index)
+		}
+	default:
+		for _, e := range entries {
+			if e.Request == nil {
+				continue
+			}
+			for _, header := range e.Request.Headers {
+				if strings.EqualFold(header.Name, "Authorization") && strings.HasPrefix(header.Value, "EG1-HMAC-SHA256 ") {
+					selected = e.Request
+					break
+				}
+			}
+			if selected != nil {
+				break
+			}
+		}
+	}
+	if selected == nil {
+		return nil, errors.New("No HAR entry has an EdgeGrid Authorization header")
+	}
+
+	var body []byte
+	if selected.PostData != nil {
+		body = []byte(selected.PostData.Text)
+	}
+	req, err := http.NewRequest(selected.Method, selected.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid HAR request: %s", err)
+	}
+	for _, header := range selected.Headers {
+		// HTTP/2 captures list pseudo-headers such as :authority.
+		if strings.HasPrefix(header.Name, ":") || strings.EqualFold(header.Name, "Host") {
+			continue
+		}
+		req.Header.Add(header.Name, header.Value)
+	}
+	return &capture{req: req, body: body}, nil
+}
+
+// splitAuthorization splits an EdgeGrid Authorization header into the part that is signed,
+// ending with the ";" before the signature, the signature and its fields.
+func splitAuthorization(header string) (string, string, map[string]string, error) {
+	const prefix = "EG1-HMAC-SHA256 "
+	i := strings.LastIndex(header, "signature=")
+	if !strings.HasPrefix(header, prefix) || i < 0 {
+		return "", "", nil, errors.New("The request has no EG1-HMAC-SHA256 Authorization header")
+	}
+
+	fields := map[string]string{}
+	for _, pair := range strings.Split(strings.TrimPrefix(header, prefix), ";") {
+		if name, value, ok := strings.Cut(pair, "="); ok {
+			fields[name] = value
+		}
+	}
+	return header[:i], header[i+len("signature="):], fields, nil
+}
+
+// parseSignedData splits data to sign, as logged by a client, into its fields. The canonical
+// headers may themselves contain tabs.
+func parseSignedData(data string) ([]string, error) {
+	data = strings.TrimRight(data, "\r\n")
+	if i := strings.Index(data, "Data to sign "); i >= 0 {
+		data = data[i+len("Data to sign "):]
+	}
+
+	parts := strings.Split(data, "\t")
+	if len(parts) < len(componentNames) {
+		return nil, fmt.Errorf("Invalid signed data, expected at least %d tab-separated fields, got %d", len(componentNames), len(parts))
+	}
+	n := len(parts)
+	return []string{
+		parts[0], parts[1], parts[2], parts[3],
+		strings.Join(parts[4:n-2], "\t"),
+		parts[n-2], parts[n-1],
+	}, nil
+}
+
+// row writes a labeled row of the verify output.
+func row(w io.Writer, label, value string) {
+	fmt.Fprintln(w, strings.TrimRight(fmt.Sprintf("  %-14s %s", label, value), " "))
+}
+
+// visible shows the tabs separating canonical headers.
+func visible(s string) string {
+	return strings.ReplaceAll(s, "\t", `\t`)
+}
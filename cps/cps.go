@@ -0,0 +1,225 @@
+// Package cps provides a read client for the Akamai Certificate Provisioning System (CPS) API:
+// enrollments, their deployments and the deployed certificates.
+//
+// CPS versions its resources through vendor media types, so each request sends the Accept
+// header of the resource version this package models.
+package cps
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// Media types of the CPS resources.
+const (
+	EnrollmentsMediaType = "application/vnd.akamai.cps.enrollments.v11+json"
+	EnrollmentMediaType  = "application/vnd.akamai.cps.enrollment.v11+json"
+	DeploymentsMediaType = "application/vnd.akamai.cps.deployments.v7+json"
+	DeploymentMediaType  = "application/vnd.akamai.cps.deployment.v7+json"
+)
+
+// Network is the Akamai network a certificate is deployed to.
+type Network string
+
+const (
+	// Staging is the Akamai staging network.
+	Staging Network = "staging"
+	// Production is the Akamai production network.
+	Production Network = "production"
+)
+
+// Enrollment is a certificate enrollment.
+type Enrollment struct {
+	Location             string               `json:"location"`
+	RA                   string               `json:"ra"`
+	ValidationType       string               `json:"validationType"`
+	CertificateType      string               `json:"certificateType"`
+	ChangeManagement     bool                 `json:"changeManagement"`
+	CSR                  CSR                  `json:"csr"`
+	NetworkConfiguration NetworkConfiguration `json:"networkConfiguration"`
+	PendingChanges       []PendingChange      `json:"pendingChanges"`
+}
+
+// ID returns the enrollment ID, the last segment of its location.
+func (e *Enrollment) ID() int {
+	id, _ := strconv.Atoi(path.Base(e.Location))
+	return id
+}
+
+// CSR holds the names of the certificate signing request.
+type CSR struct {
+	CN   string   `json:"cn"`
+	SANs []string `json:"sans"`
+	C    string   `json:"c,omitempty"`
+	ST   string   `json:"st,omitempty"`
+	L    string   `json:"l,omitempty"`
+	O    string   `json:"o,omitempty"`
+	OU   string   `json:"ou,omitempty"`
+}
+
+// NetworkConfiguration describes how the certificate is deployed.
+type NetworkConfiguration struct {
+	Geography        string `json:"geography"`
+	SecureNetwork    string `json:"secureNetwork"`
+	SNIOnly          bool   `json:"sniOnly"`
+	QUICEnabled      bool   `json:"quicEnabled"`
+	MustHaveCiphers  string `json:"mustHaveCiphers"`
+	PreferredCiphers string `json:"preferredCiphers"`
+}
+
+// PendingChange is a change in progress on an enrollment.
+type PendingChange struct {
+	Location   string `json:"location"`
+	ChangeType string `json:"changeType"`
+}
+
+// Certificate is a deployed certificate and its trust chain, PEM encoded.
+type Certificate struct {
+	Certificate string `json:"certificate"`
+	TrustChain  string `json:"trustChain"`
+}
+
+// Parse returns the parsed leaf certificate.
+func (c *Certificate) Parse() (*x509.Certificate, error) {
+	certs, err := ParsePEM(c.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("No certificate found in PEM data")
+	}
+	return certs[0], nil
+}
+
+// Chain returns the parsed trust chain certificates.
+func (c *Certificate) Chain() ([]*x509.Certificate, error) {
+	return ParsePEM(c.TrustChain)
+}
+
+// ParsePEM parses every CERTIFICATE block of PEM encoded data, skipping other blocks.
+func ParsePEM(data string) ([]*x509.Certificate, error) {
+	var (
+		certs []*x509.Certificate
+		rest  = []byte(data)
+		block *pem.Block
+	)
+	for {
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return certs, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return certs, fmt.Errorf("Unable to parse certificate: %s", err)
+		}
+		certs = append(certs, cert)
+	}
+}
+
+// Deployment is the certificates of an enrollment deployed on a network. Several certificates
+// are deployed when dual-stacked (RSA and ECDSA) or multi-stacked.
+type Deployment struct {
+	PrimaryCertificate       Certificate          `json:"primaryCertificate"`
+	MultiStackedCertificates []Certificate        `json:"multiStackedCertificates"`
+	NetworkConfiguration     NetworkConfiguration `json:"networkConfiguration"`
+}
+
+// Certificates returns the primary and multi-stacked certificates.
+func (d *Deployment) Certificates() []Certificate {
+	return append([]Certificate{d.PrimaryCertificate}, d.MultiStackedCertificates...)
+}
+
+// Expiry returns the earliest expiry date of the deployed certificates.
+func (d *Deployment) Expiry() (time.Time, error) {
+	var expiry time.Time
+	for _, c := range d.Certificates() {
+		if c.Certificate == "" {
+			continue
+		}
+		cert, err := c.Parse()
+		if err != nil {
+			return time.Time{}, err
+		}
+		if expiry.IsZero() || cert.NotAfter.Before(expiry) {
+			expiry = cert.NotAfter
+		}
+	}
+	if expiry.IsZero() {
+		return expiry, fmt.Errorf("No certificate deployed")
+	}
+	return expiry, nil
+}
+
+// Deployments holds the deployments of an enrollment on each network.
+type Deployments struct {
+	Production *Deployment `json:"production"`
+	Staging    *Deployment `json:"staging"`
+}
+
+// Client is a CPS API client.
+type Client struct {
+	client *edgegrid.Client
+}
+
+// New creates a CPS client sending requests with client.
+func New(client *edgegrid.Client) *Client {
+	return &Client{client: client}
+}
+
+// Enrollments lists the enrollments of contractID.
+func (c *Client) Enrollments(ctx context.Context, contractID string) ([]Enrollment, error) {
+	query := url.Values{}
+	query.Set("contractId", contractID)
+
+	res, err := get[struct {
+		Enrollments []Enrollment `json:"enrollments"`
+	}](ctx, c, "/cps/v2/enrollments", query, EnrollmentsMediaType)
+	return res.Enrollments, err
+}
+
+// Enrollment returns the enrollment enrollmentID.
+func (c *Client) Enrollment(ctx context.Context, enrollmentID int) (*Enrollment, error) {
+	return get[*Enrollment](ctx, c, enrollmentPath(enrollmentID), nil, EnrollmentMediaType)
+}
+
+// Deployments returns the deployments of the enrollment enrollmentID on both networks.
+func (c *Client) Deployments(ctx context.Context, enrollmentID int) (*Deployments, error) {
+	return get[*Deployments](ctx, c, enrollmentPath(enrollmentID)+"/deployments", nil, DeploymentsMediaType)
+}
+
+// Deployment returns the deployment of the enrollment enrollmentID on network.
+func (c *Client) Deployment(ctx context.Context, enrollmentID int, network Network) (*Deployment, error) {
+	return get[*Deployment](ctx, c, enrollmentPath(enrollmentID)+"/deployments/"+string(network), nil, DeploymentMediaType)
+}
+
+// get requests urlStr with query accepting mediaType and decodes the JSON response into a T.
+func get[T any](ctx context.Context, c *Client, urlStr string, query url.Values, mediaType string) (T, error) {
+	var result T
+	urlStr, err := edgegrid.WithQuery(urlStr, query)
+	if err != nil {
+		return result, err
+	}
+	req, err := c.client.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return result, err
+	}
+	req.Header.Set("Accept", mediaType)
+
+	result, _, err = edgegrid.DoJSON[T](ctx, c.client, req)
+	return result, err
+}
+
+func enrollmentPath(enrollmentID int) string {
+	return fmt.Sprintf("/cps/v2/enrollments/%d", enrollmentID)
+}
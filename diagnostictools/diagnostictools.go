@@ -0,0 +1,186 @@
+// Package diagnostictools provides a client for the Akamai Diagnostic Tools API.
+//
+// Diagnostic tools run dig, mtr and curl from Akamai edge server locations, and
+// translate Akamai URLs (ARLs) and error reference strings.
+package diagnostictools
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// LocationsResponse lists the edge server locations tools can run from.
+type LocationsResponse struct {
+	Locations []string `json:"locations"`
+}
+
+// Dig is the result of a dig run from an edge server.
+type Dig struct {
+	Hostname    string `json:"hostname"`
+	QueryType   string `json:"queryType"`
+	Result      string `json:"result"`
+	ErrorString string `json:"errorString"`
+}
+
+// DigResponse wraps a Dig result.
+type DigResponse struct {
+	Dig Dig `json:"dig"`
+}
+
+// Mtr is the result of an mtr run from an edge server.
+type Mtr struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	Host        string `json:"host"`
+	PacketLoss  string `json:"packetLoss"`
+	AvgLatency  string `json:"avgLatency"`
+	Analysis    string `json:"analysis"`
+	Result      string `json:"result"`
+	ErrorString string `json:"errorString"`
+}
+
+// MtrResponse wraps an Mtr result.
+type MtrResponse struct {
+	Mtr Mtr `json:"mtr"`
+}
+
+// CurlResults is the result of a curl request made from an edge server.
+type CurlResults struct {
+	HTTPStatusCode  int               `json:"httpStatusCode"`
+	ResponseHeaders map[string]string `json:"responseHeaders"`
+	ResponseBody    string            `json:"responseBody"`
+}
+
+// CurlResponse wraps a CurlResults result.
+type CurlResponse struct {
+	CurlResults CurlResults `json:"curlResults"`
+}
+
+// TranslatedURL describes an Akamai URL (ARL).
+type TranslatedURL struct {
+	TypeCode     string `json:"typeCode"`
+	OriginServer string `json:"originServer"`
+	CPCode       string `json:"cpCode"`
+	SerialNumber string `json:"serialNumber"`
+	TTL          string `json:"ttl"`
+}
+
+// TranslatedURLResponse wraps a TranslatedURL.
+type TranslatedURLResponse struct {
+	TranslatedURL TranslatedURL `json:"translatedUrl"`
+}
+
+// ErrorLog is a log entry of a translated error.
+type ErrorLog struct {
+	Description string            `json:"description"`
+	Fields      map[string]string `json:"fields"`
+}
+
+// TranslatedError describes the request behind an Akamai error reference string.
+type TranslatedError struct {
+	URL              string     `json:"url"`
+	HTTPResponseCode int        `json:"httpResponseCode"`
+	Timestamp        string     `json:"timestamp"`
+	EpochTime        int64      `json:"epochTime"`
+	ClientIP         string     `json:"clientIp"`
+	ConnectingIP     string     `json:"connectingIp"`
+	ServerIP         string     `json:"serverIp"`
+	OriginHostname   string     `json:"originHostname"`
+	OriginIP         string     `json:"originIp"`
+	UserAgent        string     `json:"userAgent"`
+	RequestMethod    string     `json:"requestMethod"`
+	ReasonForFailure string     `json:"reasonForFailure"`
+	WafDetails       string     `json:"wafDetails"`
+	Logs             []ErrorLog `json:"logs"`
+}
+
+// TranslatedErrorResponse wraps a TranslatedError.
+type TranslatedErrorResponse struct {
+	TranslatedError TranslatedError `json:"translatedError"`
+}
+
+// Client is a Diagnostic Tools API client.
+type Client struct {
+	client *edgegrid.Client
+}
+
+// New creates a Diagnostic Tools client sending requests with client.
+func New(client *edgegrid.Client) *Client {
+	return &Client{client: client}
+}
+
+// Locations lists the edge server locations tools can run from.
+func (c *Client) Locations(ctx context.Context) ([]string, error) {
+	res, _, err := edgegrid.GetJSON[LocationsResponse](ctx, c.client, "/diagnostic-tools/v1/locations", nil)
+	return res.Locations, err
+}
+
+// Dig runs dig for hostname with queryType (A, AAAA, CNAME, MX, ...) from location, one of Locations.
+func (c *Client) Dig(ctx context.Context, hostname, queryType, location string) (*Dig, error) {
+	query := url.Values{}
+	query.Set("hostname", hostname)
+	query.Set("queryType", queryType)
+	query.Set("location", location)
+
+	res, _, err := edgegrid.GetJSON[DigResponse](ctx, c.client, "/diagnostic-tools/v1/dig", query)
+	if err != nil {
+		return nil, err
+	}
+	return &res.Dig, nil
+}
+
+// Mtr runs mtr to destinationDomain from location, one of Locations.
+func (c *Client) Mtr(ctx context.Context, destinationDomain, location string) (*Mtr, error) {
+	query := url.Values{}
+	query.Set("destinationDomain", destinationDomain)
+	query.Set("location", location)
+
+	res, _, err := edgegrid.GetJSON[MtrResponse](ctx, c.client, "/diagnostic-tools/v1/mtr", query)
+	if err != nil {
+		return nil, err
+	}
+	return &res.Mtr, nil
+}
+
+// Curl requests rawURL from location, one of Locations, with userAgent if not empty.
+func (c *Client) Curl(ctx context.Context, rawURL, location, userAgent string) (*CurlResults, error) {
+	query := url.Values{}
+	query.Set("url", rawURL)
+	query.Set("location", location)
+	if userAgent != "" {
+		query.Set("userAgent", userAgent)
+	}
+
+	res, _, err := edgegrid.GetJSON[CurlResponse](ctx, c.client, "/diagnostic-tools/v1/curl", query)
+	if err != nil {
+		return nil, err
+	}
+	return &res.CurlResults, nil
+}
+
+// TranslateURL describes the Akamai URL (ARL) rawURL.
+func (c *Client) TranslateURL(ctx context.Context, rawURL string) (*TranslatedURL, error) {
+	query := url.Values{}
+	query.Set("url", rawURL)
+
+	res, _, err := edgegrid.GetJSON[TranslatedURLResponse](ctx, c.client, "/diagnostic-tools/v1/translatedurl", query)
+	if err != nil {
+		return nil, err
+	}
+	return &res.TranslatedURL, nil
+}
+
+// TranslateError describes the request behind errorCode, an Akamai error reference string
+// such as "9.6f64d440.1318965461.2f2b078".
+func (c *Client) TranslateError(ctx context.Context, errorCode string) (*TranslatedError, error) {
+	query := url.Values{}
+	query.Set("errorCode", errorCode)
+
+	res, _, err := edgegrid.GetJSON[TranslatedErrorResponse](ctx, c.client, "/diagnostic-tools/v1/errortranslator", query)
+	if err != nil {
+		return nil, err
+	}
+	return &res.TranslatedError, nil
+}
@@ -0,0 +1,89 @@
+package diagnostictools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/edgegridtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocations(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleJSON("GET /diagnostic-tools/v1/locations", http.StatusOK, LocationsResponse{
+		Locations: []string{"Auckland, New Zealand", "Tokyo, Japan"},
+	})
+
+	locations, err := New(s.Client()).Locations(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Auckland, New Zealand", "Tokyo, Japan"}, locations)
+}
+
+func TestDig(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleFunc("GET /diagnostic-tools/v1/dig", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "Tokyo, Japan", q.Get("location"))
+		json.NewEncoder(w).Encode(DigResponse{Dig: Dig{
+			Hostname:  q.Get("hostname"),
+			QueryType: q.Get("queryType"),
+			Result:    "developer.akamai.com. 300 IN A 23.1.2.3",
+		}})
+	})
+
+	dig, err := New(s.Client()).Dig(context.Background(), "developer.akamai.com", "A", "Tokyo, Japan")
+	assert.NoError(t, err)
+	assert.Equal(t, "developer.akamai.com", dig.Hostname)
+	assert.Equal(t, "A", dig.QueryType)
+	assert.Contains(t, dig.Result, "23.1.2.3")
+}
+
+func TestTools(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleFunc("GET /diagnostic-tools/v1/mtr", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(MtrResponse{Mtr: Mtr{Destination: r.URL.Query().Get("destinationDomain"), PacketLoss: "0.0"}})
+	})
+	s.HandleFunc("GET /diagnostic-tools/v1/curl", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-agent", r.URL.Query().Get("userAgent"))
+		w.Write([]byte(`{"curlResults":{"httpStatusCode":200,"responseHeaders":{"Server":"AkamaiGHost"},"responseBody":"ok"}}`))
+	})
+	s.HandleRaw("GET /diagnostic-tools/v1/translatedurl", http.StatusOK, "application/json",
+		[]byte(`{"translatedUrl":{"typeCode":"7","originServer":"origin.example.com","cpCode":"12345","serialNumber":"1","ttl":"1d"}}`))
+	s.HandleRaw("GET /diagnostic-tools/v1/errortranslator", http.StatusOK, "application/json",
+		[]byte(`{"translatedError":{"url":"https://www.example.com/","httpResponseCode":503,"reasonForFailure":"Origin timeout","logs":[{"description":"edge","fields":{"ghost":"1.2.3.4"}}]}}`))
+
+	c := New(s.Client())
+	ctx := context.Background()
+
+	mtr, err := c.Mtr(ctx, "www.example.com", "Tokyo, Japan")
+	assert.NoError(t, err)
+	assert.Equal(t, "www.example.com", mtr.Destination)
+
+	curl, err := c.Curl(ctx, "https://www.example.com/", "Tokyo, Japan", "test-agent")
+	assert.NoError(t, err)
+	assert.Equal(t, 200, curl.HTTPStatusCode)
+	assert.Equal(t, "AkamaiGHost", curl.ResponseHeaders["Server"])
+
+	translated, err := c.TranslateURL(ctx, "http://a1.g.akamai.net/7/1/12345/1d/origin.example.com/")
+	assert.NoError(t, err)
+	assert.Equal(t, "12345", translated.CPCode)
+
+	translatedError, err := c.TranslateError(ctx, "9.6f64d440.1318965461.2f2b078")
+	assert.NoError(t, err)
+	assert.Equal(t, 503, translatedError.HTTPResponseCode)
+	assert.Equal(t, "1.2.3.4", translatedError.Logs[0].Fields["ghost"])
+}
+
+func TestError(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+
+	_, err := New(s.Client()).Dig(context.Background(), "developer.akamai.com", "A", "Nowhere")
+	assert.IsType(t, &edgegrid.Error{}, err)
+}
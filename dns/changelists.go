@@ -0,0 +1,106 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// ChangeOp is the operation of a changelist change.
+type ChangeOp string
+
+const (
+	// Add adds a record set.
+	Add ChangeOp = "ADD"
+	// Edit replaces a record set.
+	Edit ChangeOp = "EDIT"
+	// Delete deletes a record set.
+	Delete ChangeOp = "DELETE"
+)
+
+// Changelist is a staged set of changes to a zone, applied at once when submitted.
+type Changelist struct {
+	Zone             string `json:"zone"`
+	ChangeTag        string `json:"changeTag"`
+	ZoneVersionID    string `json:"zoneVersionId"`
+	LastModifiedDate string `json:"lastModifiedDate"`
+	Stale            bool   `json:"stale"`
+}
+
+// Change is a record set change added to a changelist.
+type Change struct {
+	RecordSet
+	Op ChangeOp `json:"op"`
+}
+
+// CreateChangelist creates a changelist for zone from its current version.
+func (c *Client) CreateChangelist(ctx context.Context, zone string) (*Changelist, error) {
+	query := url.Values{}
+	query.Set("zone", zone)
+
+	path, err := edgegrid.WithQuery("/config-dns/v2/changelists", query)
+	if err != nil {
+		return nil, err
+	}
+	cl, _, err := edgegrid.SendJSON[*struct{}, *Changelist](ctx, c.client, "POST", path, nil)
+	return cl, err
+}
+
+// Changelist returns the changelist of zone.
+func (c *Client) Changelist(ctx context.Context, zone string) (*Changelist, error) {
+	cl, _, err := edgegrid.GetJSON[*Changelist](ctx, c.client, changelistPath(zone), nil)
+	return cl, err
+}
+
+// ChangelistRecordSets returns the record sets of zone as they will be once the changelist is submitted.
+func (c *Client) ChangelistRecordSets(ctx context.Context, zone string) ([]RecordSet, error) {
+	res, _, err := edgegrid.GetJSON[struct {
+		RecordSets []RecordSet `json:"recordSets"`
+	}](ctx, c.client, changelistPath(zone)+"/recordsets", nil)
+	return res.RecordSets, err
+}
+
+// AddChange adds change to the changelist of zone.
+func (c *Client) AddChange(ctx context.Context, zone string, change Change) error {
+	_, _, err := edgegrid.SendJSON[Change, struct{}](ctx, c.client, "POST", changelistPath(zone)+"/recordsets/add-change", change)
+	return err
+}
+
+// SubmitChangelist applies the changelist of zone, creating a new zone version.
+func (c *Client) SubmitChangelist(ctx context.Context, zone string) error {
+	_, _, err := edgegrid.SendJSON[*struct{}, struct{}](ctx, c.client, "POST", changelistPath(zone)+"/submit", nil)
+	return err
+}
+
+// DiscardChangelist deletes the changelist of zone without applying it.
+func (c *Client) DiscardChangelist(ctx context.Context, zone string) error {
+	_, _, err := edgegrid.SendJSON[*struct{}, struct{}](ctx, c.client, "DELETE", changelistPath(zone), nil)
+	return err
+}
+
+// ApplyChanges applies changes to zone in a single zone version: it creates a changelist, adds
+// every change and submits it. If any step fails the changelist is discarded.
+func (c *Client) ApplyChanges(ctx context.Context, zone string, changes ...Change) error {
+	if _, err := c.CreateChangelist(ctx, zone); err != nil {
+		return err
+	}
+
+	for i, change := range changes {
+		if err := c.AddChange(ctx, zone, change); err != nil {
+			c.DiscardChangelist(ctx, zone)
+			return fmt.Errorf("Unable to add change %d (%s %s %s): %w", i, change.Op, change.Name, change.Type, err)
+		}
+	}
+
+	if err := c.SubmitChangelist(ctx, zone); err != nil {
+		c.DiscardChangelist(ctx, zone)
+		return err
+	}
+	return nil
+}
+
+func changelistPath(zone string) string {
+	return "/config-dns/v2/changelists/" + url.PathEscape(zone)
+}
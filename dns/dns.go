@@ -0,0 +1,112 @@
+// Package dns provides a client for the Akamai Edge DNS (Config DNS v2) API.
+//
+// It covers zones, record sets, changelists (the staged bulk edit flow) and
+// zone file import and export. ParseZoneFile and FormatZoneFile convert
+// between BIND zone files and record sets locally.
+package dns
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// Zone types.
+const (
+	Primary   = "PRIMARY"
+	Secondary = "SECONDARY"
+	Alias     = "ALIAS"
+)
+
+// Zone is an Edge DNS zone.
+type Zone struct {
+	Zone                  string   `json:"zone"`
+	Type                  string   `json:"type"`
+	Comment               string   `json:"comment,omitempty"`
+	ContractID            string   `json:"contractId,omitempty"`
+	Masters               []string `json:"masters,omitempty"`
+	Target                string   `json:"target,omitempty"`
+	EndCustomerID         string   `json:"endCustomerId,omitempty"`
+	SignAndServe          bool     `json:"signAndServe"`
+	SignAndServeAlgorithm string   `json:"signAndServeAlgorithm,omitempty"`
+	ActivationState       string   `json:"activationState,omitempty"`
+	LastActivationDate    string   `json:"lastActivationDate,omitempty"`
+	LastModifiedDate      string   `json:"lastModifiedDate,omitempty"`
+	LastModifiedBy        string   `json:"lastModifiedBy,omitempty"`
+	VersionID             string   `json:"versionId,omitempty"`
+}
+
+// Client is an Edge DNS API client.
+type Client struct {
+	client *edgegrid.Client
+
+	// PageSize is the number of items requested per page by list methods.
+	PageSize int
+}
+
+// New creates an Edge DNS client sending requests with client.
+func New(client *edgegrid.Client) *Client {
+	return &Client{client: client, PageSize: 100}
+}
+
+// Zones lists the zones of the account, optionally limited to contractIDs.
+func (c *Client) Zones(ctx context.Context, contractIDs ...string) ([]Zone, error) {
+	query := url.Values{}
+	for _, id := range contractIDs {
+		query.Add("contractIds", id)
+	}
+
+	path, err := edgegrid.WithQuery("/config-dns/v2/zones", query)
+	if err != nil {
+		return nil, err
+	}
+	p := edgegrid.NewPaginator(c.client, path, edgegrid.NewPageNumber(c.PageSize), edgegrid.ItemsAt[Zone]("zones"))
+	return p.Collect(ctx)
+}
+
+// Zone returns the zone named zone.
+func (c *Client) Zone(ctx context.Context, zone string) (*Zone, error) {
+	z, _, err := edgegrid.GetJSON[*Zone](ctx, c.client, zonePath(zone), nil)
+	return z, err
+}
+
+// CreateZone creates zone in contractID and, if not empty, groupID.
+func (c *Client) CreateZone(ctx context.Context, contractID, groupID string, zone *Zone) (*Zone, error) {
+	query := url.Values{}
+	query.Set("contractId", contractID)
+	if groupID != "" {
+		query.Set("gid", groupID)
+	}
+
+	path, err := edgegrid.WithQuery("/config-dns/v2/zones", query)
+	if err != nil {
+		return nil, err
+	}
+	created, _, err := edgegrid.SendJSON[*Zone, *Zone](ctx, c.client, "POST", path, zone)
+	return created, err
+}
+
+// UpdateZone updates the settings of zone.
+func (c *Client) UpdateZone(ctx context.Context, zone *Zone) (*Zone, error) {
+	updated, _, err := edgegrid.SendJSON[*Zone, *Zone](ctx, c.client, "PUT", zonePath(zone.Zone), zone)
+	return updated, err
+}
+
+// DeleteZones requests the deletion of zones and returns the ID of the delete request.
+// Deletion is asynchronous; force deletes zones that still have delegations.
+func (c *Client) DeleteZones(ctx context.Context, force bool, zones ...string) (string, error) {
+	path := "/config-dns/v2/zones/delete-requests"
+	if force {
+		path += "?force=true"
+	}
+
+	res, _, err := edgegrid.SendJSON[map[string][]string, struct {
+		RequestID string `json:"requestId"`
+	}](ctx, c.client, "POST", path, map[string][]string{"zones": zones})
+	return res.RequestID, err
+}
+
+func zonePath(zone string) string {
+	return "/config-dns/v2/zones/" + url.PathEscape(zone)
+}
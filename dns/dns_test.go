@@ -0,0 +1,191 @@
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/edgegridtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZones(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleFunc("GET /config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"1-1TJZFB"}, r.URL.Query()["contractIds"])
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		zones := []Zone{}
+		if page == 1 {
+			zones = []Zone{{Zone: "example.com", Type: Primary}, {Zone: "example.net", Type: Secondary, Masters: []string{"192.0.2.1"}}}
+		} else if page == 2 {
+			zones = []Zone{{Zone: "example.org", Type: Alias, Target: "example.com"}}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"zones": zones})
+	})
+	s.HandleFunc("POST /config-dns/v2/zones", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1-1TJZFB", r.URL.Query().Get("contractId"))
+		assert.Equal(t, "15225", r.URL.Query().Get("gid"))
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"zone":"new.example.com","type":"PRIMARY","activationState":"NEW"}`))
+	})
+	s.HandleJSON("POST /config-dns/v2/zones/delete-requests", http.StatusCreated, map[string]string{"requestId": "15bc138f-8d82-451b-80b7-a56b88ffc474"})
+
+	c := New(s.Client())
+	c.PageSize = 2
+	ctx := context.Background()
+
+	zones, err := c.Zones(ctx, "1-1TJZFB")
+	assert.NoError(t, err)
+	assert.Len(t, zones, 3)
+	assert.Equal(t, "example.org", zones[2].Zone)
+
+	created, err := c.CreateZone(ctx, "1-1TJZFB", "15225", &Zone{Zone: "new.example.com", Type: Primary})
+	assert.NoError(t, err)
+	assert.Equal(t, "NEW", created.ActivationState)
+
+	id, err := c.DeleteZones(ctx, true, "new.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "15bc138f-8d82-451b-80b7-a56b88ffc474", id)
+	last := s.Requests()[len(s.Requests())-1]
+	assert.Equal(t, "true", last.Query.Get("force"))
+	assert.JSONEq(t, `{"zones":["new.example.com"]}`, string(last.Body))
+}
+
+func TestRecordSets(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleJSON("GET /config-dns/v2/zones/example.com/recordsets", http.StatusOK, recordSetsBody{RecordSets: []RecordSet{
+		{Name: "www.example.com", Type: A, TTL: 300, Rdata: []string{"192.0.2.10", "192.0.2.11"}},
+	}})
+	s.HandleFunc("/config-dns/v2/zones/example.com/names/{name}/types/{type}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		case "GET":
+			json.NewEncoder(w).Encode(RecordSet{Name: r.PathValue("name"), Type: r.PathValue("type"), TTL: 3600, Rdata: []string{"10 mail.example.com."}})
+		default:
+			w.WriteHeader(http.StatusCreated)
+			body := RecordSet{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			json.NewEncoder(w).Encode(body)
+		}
+	})
+
+	c := New(s.Client())
+	ctx := context.Background()
+
+	recordSets, err := c.RecordSets(ctx, "example.com", A, AAAA)
+	assert.NoError(t, err)
+	assert.Len(t, recordSets, 1)
+	assert.Equal(t, "A,AAAA", s.Requests()[0].Query.Get("types"))
+
+	mx, err := c.RecordSet(ctx, "example.com", "example.com", MX)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10 mail.example.com."}, mx.Rdata)
+
+	txt := &RecordSet{Name: "example.com", Type: TXT, TTL: 300, Rdata: []string{`"v=spf1 -all"`}}
+	created, err := c.CreateRecordSet(ctx, "example.com", txt)
+	assert.NoError(t, err)
+	assert.Equal(t, txt, created)
+	assert.Equal(t, "/config-dns/v2/zones/example.com/names/example.com/types/TXT", s.Requests()[2].Path)
+
+	assert.NoError(t, c.DeleteRecordSet(ctx, "example.com", "example.com", TXT))
+	assert.Equal(t, "DELETE", s.Requests()[3].Method)
+
+	_, err = c.RecordSet(ctx, "missing.com", "missing.com", A)
+	assert.IsType(t, &edgegrid.Error{}, err)
+}
+
+// fakeChangelists keeps the changelist state of a single zone.
+type fakeChangelists struct {
+	open      bool
+	changes   []Change
+	submitted []Change
+}
+
+func newChangelistServer(t *testing.T, failOn string) (*edgegridtest.Server, *fakeChangelists) {
+	api := &fakeChangelists{}
+	s := edgegridtest.NewServer()
+	s.HandleFunc("POST /config-dns/v2/changelists", func(w http.ResponseWriter, r *http.Request) {
+		if api.open {
+			edgegridtest.WriteProblem(w, r, http.StatusConflict, "Conflict", "changelist exists")
+			return
+		}
+		api.open = true
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"zone":%q,"changeTag":"476754f4-d605-479f-853b-db854d7254fa","zoneVersionId":"1d9c887c","stale":false}`, r.URL.Query().Get("zone"))
+	})
+	s.HandleFunc("POST /config-dns/v2/changelists/example.com/recordsets/add-change", func(w http.ResponseWriter, r *http.Request) {
+		change := Change{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&change))
+		if change.Name == failOn {
+			edgegridtest.WriteProblem(w, r, http.StatusBadRequest, "Invalid Record", "bad rdata")
+			return
+		}
+		api.changes = append(api.changes, change)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s.HandleFunc("POST /config-dns/v2/changelists/example.com/submit", func(w http.ResponseWriter, r *http.Request) {
+		api.submitted, api.changes, api.open = api.changes, nil, false
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s.HandleFunc("DELETE /config-dns/v2/changelists/example.com", func(w http.ResponseWriter, r *http.Request) {
+		api.changes, api.open = nil, false
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return s, api
+}
+
+func TestApplyChanges(t *testing.T) {
+	s, api := newChangelistServer(t, "bad.example.com")
+	defer s.Close()
+	c := New(s.Client())
+	ctx := context.Background()
+
+	err := c.ApplyChanges(ctx, "example.com",
+		Change{Op: Add, RecordSet: RecordSet{Name: "www.example.com", Type: CNAME, TTL: 300, Rdata: []string{"example.com.edgekey.net."}}},
+		Change{Op: Delete, RecordSet: RecordSet{Name: "old.example.com", Type: A}},
+	)
+	assert.NoError(t, err)
+	assert.Len(t, api.submitted, 2)
+	assert.False(t, api.open)
+	assert.JSONEq(t, `{"name":"www.example.com","type":"CNAME","ttl":300,"rdata":["example.com.edgekey.net."],"op":"ADD"}`, string(s.Requests()[1].Body))
+
+	err = c.ApplyChanges(ctx, "example.com",
+		Change{Op: Add, RecordSet: RecordSet{Name: "ok.example.com", Type: A, TTL: 300, Rdata: []string{"192.0.2.1"}}},
+		Change{Op: Add, RecordSet: RecordSet{Name: "bad.example.com", Type: A, TTL: 300, Rdata: []string{"nope"}}},
+	)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "bad.example.com")
+	assert.False(t, api.open)
+	assert.Len(t, api.submitted, 2)
+}
+
+func TestZoneFileImportExport(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleRaw("GET /config-dns/v2/zones/example.com/zone-file", http.StatusOK, "text/dns", []byte("example.com. 300 IN A 192.0.2.1\n"))
+	s.HandleFunc("POST /config-dns/v2/zones/example.com/zone-file", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/dns", r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	c := New(s.Client())
+	ctx := context.Background()
+
+	data, err := c.ExportZoneFile(ctx, "example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com. 300 IN A 192.0.2.1\n", string(data))
+	assert.Equal(t, "text/dns", s.Requests()[0].Header.Get("Accept"))
+
+	assert.NoError(t, c.ImportZoneFile(ctx, "example.com", data))
+	assert.Equal(t, data, s.Requests()[1].Body)
+
+	_, err = c.ExportZoneFile(ctx, "missing.com")
+	assert.IsType(t, &edgegrid.Error{}, err)
+}
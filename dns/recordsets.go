@@ -0,0 +1,86 @@
+package dns
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// Record types.
+const (
+	A     = "A"
+	AAAA  = "AAAA"
+	CAA   = "CAA"
+	CNAME = "CNAME"
+	MX    = "MX"
+	NS    = "NS"
+	PTR   = "PTR"
+	SOA   = "SOA"
+	SRV   = "SRV"
+	TXT   = "TXT"
+)
+
+// RecordSet is the set of records of one type at one name. Name is fully qualified without the
+// trailing dot, e.g. "www.example.com". Rdata holds each record's data in zone file presentation
+// format, e.g. "10 mail.example.com." for MX or "\"v=spf1 -all\"" for TXT.
+type RecordSet struct {
+	Name  string   `json:"name"`
+	Type  string   `json:"type"`
+	TTL   int      `json:"ttl"`
+	Rdata []string `json:"rdata"`
+}
+
+type recordSetsBody struct {
+	RecordSets []RecordSet `json:"recordsets"`
+}
+
+// RecordSets lists the record sets of zone, optionally limited to types.
+func (c *Client) RecordSets(ctx context.Context, zone string, types ...string) ([]RecordSet, error) {
+	query := url.Values{}
+	if len(types) > 0 {
+		query.Set("types", strings.Join(types, ","))
+	}
+
+	path, err := edgegrid.WithQuery(zonePath(zone)+"/recordsets", query)
+	if err != nil {
+		return nil, err
+	}
+	p := edgegrid.NewPaginator(c.client, path, edgegrid.NewPageNumber(c.PageSize), edgegrid.ItemsAt[RecordSet]("recordsets"))
+	return p.Collect(ctx)
+}
+
+// RecordSet returns the record set of type recordType at name in zone.
+func (c *Client) RecordSet(ctx context.Context, zone, name, recordType string) (*RecordSet, error) {
+	rs, _, err := edgegrid.GetJSON[*RecordSet](ctx, c.client, recordSetPath(zone, name, recordType), nil)
+	return rs, err
+}
+
+// CreateRecordSet creates rs in zone.
+func (c *Client) CreateRecordSet(ctx context.Context, zone string, rs *RecordSet) (*RecordSet, error) {
+	created, _, err := edgegrid.SendJSON[*RecordSet, *RecordSet](ctx, c.client, "POST", recordSetPath(zone, rs.Name, rs.Type), rs)
+	return created, err
+}
+
+// UpdateRecordSet replaces the record set of rs's name and type in zone with rs.
+func (c *Client) UpdateRecordSet(ctx context.Context, zone string, rs *RecordSet) (*RecordSet, error) {
+	updated, _, err := edgegrid.SendJSON[*RecordSet, *RecordSet](ctx, c.client, "PUT", recordSetPath(zone, rs.Name, rs.Type), rs)
+	return updated, err
+}
+
+// DeleteRecordSet deletes the record set of type recordType at name in zone.
+func (c *Client) DeleteRecordSet(ctx context.Context, zone, name, recordType string) error {
+	_, _, err := edgegrid.SendJSON[*struct{}, struct{}](ctx, c.client, "DELETE", recordSetPath(zone, name, recordType), nil)
+	return err
+}
+
+// ReplaceRecordSets replaces every record set of zone, except SOA and apex NS if omitted, with recordSets.
+func (c *Client) ReplaceRecordSets(ctx context.Context, zone string, recordSets []RecordSet) error {
+	_, _, err := edgegrid.SendJSON[recordSetsBody, struct{}](ctx, c.client, "PUT", zonePath(zone)+"/recordsets", recordSetsBody{RecordSets: recordSets})
+	return err
+}
+
+func recordSetPath(zone, name, recordType string) string {
+	return zonePath(zone) + "/names/" + url.PathEscape(name) + "/types/" + url.PathEscape(recordType)
+}
@@ -0,0 +1,266 @@
+package dns
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ExportZoneFile returns the zone file of zone in BIND format.
+func (c *Client) ExportZoneFile(ctx context.Context, zone string) ([]byte, error) {
+	req, err := c.client.NewRequest("GET", zonePath(zone)+"/zone-file", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/dns")
+
+	_, body, err := c.client.Send(ctx, req)
+	return body, err
+}
+
+// ImportZoneFile replaces the records of zone with those of a BIND format zone file.
+func (c *Client) ImportZoneFile(ctx context.Context, zone string, data []byte) error {
+	req, err := c.client.NewRequest("POST", zonePath(zone)+"/zone-file", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/dns")
+	req.Header.Set("Accept", "application/json")
+
+	_, _, err = c.client.Send(ctx, req)
+	return err
+}
+
+// FormatZoneFile writes recordSets of zone as a BIND format zone file.
+func FormatZoneFile(w io.Writer, zone string, recordSets []RecordSet) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "$ORIGIN %s.\n", strings.TrimSuffix(zone, "."))
+	for _, rs := range recordSets {
+		for _, rdata := range rs.Rdata {
+			fmt.Fprintf(bw, "%s.\t%d\tIN\t%s\t%s\n", strings.TrimSuffix(rs.Name, "."), rs.TTL, rs.Type, rdata)
+		}
+	}
+	return bw.Flush()
+}
+
+// ParseZoneFile reads a BIND format zone file for zone and returns its records grouped into
+// record sets, in order of first appearance. $ORIGIN and $TTL directives, relative and "@" owner
+// names, omitted owners, comments and parenthesized multi-line records are supported. Rdata is
+// kept as written, with whitespace normalized; names in rdata are not qualified.
+func ParseZoneFile(r io.Reader, zone string) ([]RecordSet, error) {
+	lines, err := zoneLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		origin     = strings.TrimSuffix(zone, ".")
+		defaultTTL = -1
+		owner      string
+		recordSets []RecordSet
+		index      = map[string]int{}
+	)
+	for _, line := range lines {
+		tokens := line.tokens
+
+		if strings.HasPrefix(tokens[0], "$") {
+			if len(tokens) < 2 {
+				return nil, fmt.Errorf("Line %d: missing argument to %s", line.number, tokens[0])
+			}
+			switch strings.ToUpper(tokens[0]) {
+			case "$ORIGIN":
+				origin = qualify(tokens[1], origin)
+			case "$TTL":
+				ttl, ok := parseTTL(tokens[1])
+				if !ok {
+					return nil, fmt.Errorf("Line %d: invalid TTL %q", line.number, tokens[1])
+				}
+				defaultTTL = ttl
+			default:
+				return nil, fmt.Errorf("Line %d: unsupported directive %s", line.number, tokens[0])
+			}
+			continue
+		}
+
+		if !line.continued {
+			owner = qualify(tokens[0], origin)
+			tokens = tokens[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("Line %d: record without owner name", line.number)
+		}
+
+		ttl := defaultTTL
+		for len(tokens) > 0 {
+			if value, ok := parseTTL(tokens[0]); ok {
+				ttl = value
+			} else if !isClass(tokens[0]) {
+				break
+			}
+			tokens = tokens[1:]
+		}
+		if len(tokens) < 2 {
+			return nil, fmt.Errorf("Line %d: missing record type or data", line.number)
+		}
+		if ttl < 0 {
+			return nil, fmt.Errorf("Line %d: no TTL and no $TTL directive", line.number)
+		}
+
+		recordType := strings.ToUpper(tokens[0])
+		rdata := strings.Join(tokens[1:], " ")
+
+		key := strings.ToLower(owner) + " " + recordType
+		if i, ok := index[key]; ok {
+			recordSets[i].Rdata = append(recordSets[i].Rdata, rdata)
+			continue
+		}
+		index[key] = len(recordSets)
+		recordSets = append(recordSets, RecordSet{Name: owner, Type: recordType, TTL: ttl, Rdata: []string{rdata}})
+	}
+
+	return recordSets, nil
+}
+
+type zoneLine struct {
+	number    int
+	continued bool
+	tokens    []string
+}
+
+// zoneLines splits a zone file into logical lines of tokens, joining parenthesized lines and
+// dropping comments. continued is set for lines starting with whitespace, which reuse the
+// previous owner name.
+func zoneLines(r io.Reader) ([]zoneLine, error) {
+	var (
+		lines   []zoneLine
+		current *zoneLine
+		depth   int
+	)
+
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		text := scanner.Text()
+		if current == nil {
+			current = &zoneLine{number: number, continued: len(text) > 0 && (text[0] == ' ' || text[0] == '\t')}
+		}
+
+		token := strings.Builder{}
+		inToken, quoted := false, false
+		flush := func() {
+			if inToken {
+				current.tokens = append(current.tokens, token.String())
+				token.Reset()
+				inToken = false
+			}
+		}
+
+	chars:
+		for i := 0; i < len(text); i++ {
+			ch := text[i]
+			switch {
+			case quoted:
+				token.WriteByte(ch)
+				if ch == '\\' && i+1 < len(text) {
+					i++
+					token.WriteByte(text[i])
+				} else if ch == '"' {
+					quoted = false
+				}
+			case ch == '"':
+				token.WriteByte(ch)
+				inToken, quoted = true, true
+			case ch == ';':
+				break chars
+			case ch == '(':
+				flush()
+				depth++
+			case ch == ')':
+				flush()
+				if depth == 0 {
+					return nil, fmt.Errorf("Line %d: unbalanced parenthesis", number)
+				}
+				depth--
+			case ch == ' ' || ch == '\t':
+				flush()
+			default:
+				token.WriteByte(ch)
+				inToken = true
+			}
+		}
+		if quoted {
+			return nil, fmt.Errorf("Line %d: unterminated string", number)
+		}
+		flush()
+
+		if depth > 0 {
+			continue
+		}
+		if len(current.tokens) > 0 {
+			lines = append(lines, *current)
+		}
+		current = nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("Line %d: unbalanced parenthesis", current.number)
+	}
+
+	return lines, nil
+}
+
+// qualify returns name relative to origin as a fully qualified name without the trailing dot.
+func qualify(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, ".")
+	case origin == "":
+		return name
+	}
+	return name + "." + origin
+}
+
+// parseTTL parses a TTL in seconds or with BIND unit suffixes, e.g. "3600" or "1h30m".
+func parseTTL(s string) (int, bool) {
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		return 0, false
+	}
+	if ttl, err := strconv.Atoi(s); err == nil {
+		return ttl, true
+	}
+
+	units := map[byte]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	total, value, digits := 0, 0, false
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if ch >= '0' && ch <= '9' {
+			value = value*10 + int(ch-'0')
+			digits = true
+			continue
+		}
+		unit, ok := units[ch|0x20]
+		if !ok || !digits {
+			return 0, false
+		}
+		total += value * unit
+		value, digits = 0, false
+	}
+	if digits {
+		return 0, false
+	}
+	return total, true
+}
+
+func isClass(s string) bool {
+	switch strings.ToUpper(s) {
+	case "IN", "CH", "HS", "CS":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package dns
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const zoneFile = `$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	a1-1.akam.net. hostmaster.example.com. (
+		2024010101 ; serial
+		3600       ; refresh
+		600 604800 300 )
+@		86400	IN	NS	a1-1.akam.net.
+		86400	IN	NS	a2-2.akam.net.
+www		300	A	192.0.2.10
+www		300	A	192.0.2.11
+mail	IN	MX	10 mail.example.com.
+example.com.	IN	TXT	"v=spf1 include:_spf.example.com -all"   ; SPF
+_sip._tcp	600	IN	SRV	10 60 5060 sip.example.com.
+@	CAA	0 issue "letsencrypt.org; validationmethods=dns-01"
+cdn.example.org.	1d	CNAME	example.com.edgekey.net.
+`
+
+func TestParseZoneFile(t *testing.T) {
+	recordSets, err := ParseZoneFile(strings.NewReader(zoneFile), "example.com")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []RecordSet{
+		{Name: "example.com", Type: SOA, TTL: 3600, Rdata: []string{"a1-1.akam.net. hostmaster.example.com. 2024010101 3600 600 604800 300"}},
+		{Name: "example.com", Type: NS, TTL: 86400, Rdata: []string{"a1-1.akam.net.", "a2-2.akam.net."}},
+		{Name: "www.example.com", Type: A, TTL: 300, Rdata: []string{"192.0.2.10", "192.0.2.11"}},
+		{Name: "mail.example.com", Type: MX, TTL: 3600, Rdata: []string{"10 mail.example.com."}},
+		{Name: "example.com", Type: TXT, TTL: 3600, Rdata: []string{`"v=spf1 include:_spf.example.com -all"`}},
+		{Name: "_sip._tcp.example.com", Type: SRV, TTL: 600, Rdata: []string{"10 60 5060 sip.example.com."}},
+		{Name: "example.com", Type: CAA, TTL: 3600, Rdata: []string{`0 issue "letsencrypt.org; validationmethods=dns-01"`}},
+		{Name: "cdn.example.org", Type: CNAME, TTL: 86400, Rdata: []string{"example.com.edgekey.net."}},
+	}, recordSets)
+}
+
+func TestFormatZoneFileRoundTrip(t *testing.T) {
+	recordSets, err := ParseZoneFile(strings.NewReader(zoneFile), "example.com")
+	assert.NoError(t, err)
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, FormatZoneFile(&buf, "example.com", recordSets))
+	assert.Contains(t, buf.String(), "$ORIGIN example.com.\n")
+	assert.Contains(t, buf.String(), "www.example.com.\t300\tIN\tA\t192.0.2.11\n")
+
+	reparsed, err := ParseZoneFile(&buf, "example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, recordSets, reparsed)
+}
+
+func TestParseZoneFileErrors(t *testing.T) {
+	matrix := []struct {
+		zone string
+		err  string
+	}{
+		{"www A 192.0.2.1\n", "Line 1: no TTL"},
+		{"$TTL 300\n@ SOA a. b. ( 1 2 3\n", "Line 2: unbalanced parenthesis"},
+		{"$TTL 300\nwww TXT \"open\n", "Line 2: unterminated string"},
+		{"$INCLUDE other.zone\n", "Line 1: unsupported directive"},
+		{"$TTL 300\nwww 300\n", "Line 2: missing record type"},
+		{"$TTL 1x\n", "Line 1: invalid TTL"},
+	}
+	for _, tt := range matrix {
+		_, err := ParseZoneFile(strings.NewReader(tt.zone), "example.com")
+		if assert.Error(t, err, tt.zone) {
+			assert.Contains(t, err.Error(), tt.err)
+		}
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	for s, want := range map[string]int{"300": 300, "1h": 3600, "1h30m": 5400, "1W": 604800, "2d": 172800} {
+		ttl, ok := parseTTL(s)
+		assert.True(t, ok, s)
+		assert.Equal(t, want, ttl, s)
+	}
+	for _, s := range []string{"", "IN", "1x", "h1", "1h3"} {
+		_, ok := parseTTL(s)
+		assert.False(t, ok, s)
+	}
+}
@@ -31,24 +31,27 @@ type Config struct {
 	ClientToken  string   `ini:"client_token"`
 	ClientSecret string   `ini:"client_secret"`
 	AccessToken  string   `ini:"access_token"`
+	AccountKey   string   `ini:"account_key"`
 	HeaderToSign []string `ini:"headers_to_sign"`
 	MaxBody      int      `ini:"max_body"`
 	Debug        bool     `ini:"debug"`
 }
 
+// MakeEdgeTimeStamp returns the current time as a signing timestamp.
 // Must be assigned the UTC time when the request is signed.
 // Format of “yyyyMMddTHH:mm:ss+0000”
-func makeEdgeTimeStamp() string {
+func MakeEdgeTimeStamp() string {
 	local := time.FixedZone("GMT", 0)
 	t := time.Now().In(local)
 	return fmt.Sprintf("%d%02d%02dT%02d:%02d:%02d+0000",
 		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second())
 }
 
+// CreateNonce returns a random UUID as a signing nonce, or an empty string if none can be generated.
 // Must be assigned a nonce (number used once) for the request.
 // It is a random string used to detect replayed request messages.
 // A GUID is recommended.
-func createNonce() string {
+func CreateNonce() string {
 	uuid, err := securerandom.Uuid()
 	if err != nil {
 		log.Errorf("Generate Uuid failed, %s", err)
@@ -157,8 +160,17 @@ func (c *Config) createContentHash(req *http.Request) string {
 // This data set comprised of the request data combined with the authorization header value (excluding the signature field,
 // but including the ; right before the signature field).
 func (c *Config) signingData(req *http.Request, authHeader string) string {
+	dataSign := c.SigningComponents(req, authHeader)
+	log.Debugf("Data to sign %s", strings.Join(dataSign, "\t"))
+	return strings.Join(dataSign, "\t")
+}
 
-	dataSign := []string{
+// SigningComponents returns the fields of the data signed for req, which are joined with tabs
+// before signing: the method, scheme, host, path and query, canonical headers, content hash and
+// authHeader, the authorization header up to and including the ";" before its signature.
+// It is meant for diagnosing signature mismatches. The request body is left readable.
+func (c Config) SigningComponents(req *http.Request, authHeader string) []string {
+	return []string{
 		req.Method,
 		req.URL.Scheme,
 		req.URL.Host,
@@ -167,8 +179,6 @@ func (c *Config) signingData(req *http.Request, authHeader string) string {
 		c.createContentHash(req),
 		authHeader,
 	}
-	log.Debugf("Data to sign %s", strings.Join(dataSign, "\t"))
-	return strings.Join(dataSign, "\t")
 }
 
 func (c *Config) signingRequest(req *http.Request, authHeader string, timestamp string) string {
@@ -200,17 +210,89 @@ func AddRequestHeader(c Config, req *http.Request) *http.Request {
 }
 
 func (c Config) AddRequestHeader(req *http.Request) *http.Request {
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.SignRequest(req, MakeEdgeTimeStamp(), CreateNonce())
+}
+
+// SignRequest sets the authorization header of req computed with the given timestamp, in the
+// "20060102T15:04:05+0000" format, and nonce. Unlike AddRequestHeader it adds no other header.
+// It is meant for reproducing a known signature or signing for external tools; requests sent
+// to Akamai need a current timestamp and a fresh nonce.
+func (c Config) SignRequest(req *http.Request, timestamp string, nonce string) *http.Request {
 	if c.Debug {
 		log.SetLevel(log.DebugLevel)
 	}
-	timestamp := makeEdgeTimeStamp()
-	nonce := createNonce()
-
-	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", c.createAuthHeader(req, timestamp, nonce))
 	return req
 }
 
+// VerifyRequest checks the EdgeGrid Authorization header of a received request against the credentials in c.
+// It is the server side counterpart of AddRequestHeader and is intended for test servers standing in for Akamai.
+// The request body is left readable.
+func (c Config) VerifyRequest(req *http.Request) error {
+	fields, err := parseAuthHeader(req.Header.Get("Authorization"))
+	if err != nil {
+		return err
+	}
+
+	if fields["client_token"] != c.ClientToken || fields["access_token"] != c.AccessToken {
+		return fmt.Errorf("Invalid client_token or access_token")
+	}
+
+	// Requests received by a server have no scheme or host in their URL
+	signed := *req
+	u := *req.URL
+	signed.URL = &u
+	if signed.URL.Host == "" {
+		signed.URL.Host = req.Host
+	}
+	if signed.URL.Scheme == "" {
+		signed.URL.Scheme = "http"
+		if req.TLS != nil {
+			signed.URL.Scheme = "https"
+		}
+	}
+
+	authHeader := fmt.Sprintf("EG1-HMAC-SHA256 client_token=%s;access_token=%s;timestamp=%s;nonce=%s;",
+		fields["client_token"],
+		fields["access_token"],
+		fields["timestamp"],
+		fields["nonce"],
+	)
+	expected := c.signingRequest(&signed, authHeader, fields["timestamp"])
+	req.Body = signed.Body
+
+	if !hmac.Equal([]byte(expected), []byte(fields["signature"])) {
+		return fmt.Errorf("The signature does not match")
+	}
+
+	return nil
+}
+
+func parseAuthHeader(header string) (map[string]string, error) {
+	if !strings.HasPrefix(header, "EG1-HMAC-SHA256 ") {
+		return nil, fmt.Errorf("Missing or unsupported Authorization header")
+	}
+
+	fields := map[string]string{}
+	for _, pair := range strings.Split(strings.TrimPrefix(header, "EG1-HMAC-SHA256 "), ";") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+	}
+
+	for _, field := range []string{"client_token", "access_token", "timestamp", "nonce", "signature"} {
+		if fields[field] == "" {
+			return nil, fmt.Errorf("Authorization header is missing %s", field)
+		}
+	}
+
+	return fields, nil
+}
+
 // InitConfig initializes configuration file
 func InitEdgeRc(filepath string, section string) (Config, error) {
 	var (
@@ -303,6 +385,7 @@ func InitEnv(section string) (Config, error) {
 		return c, fmt.Errorf("Fatal missing required environment variables: %s", missing)
 	}
 
+	c.AccountKey = os.Getenv(prefix + "ACCOUNT_KEY")
 	c.MaxBody = 0
 
 	val, ok := os.LookupEnv(prefix + "MAX_BODY")
@@ -327,38 +410,76 @@ func InitConfig(filepath string, section string) Config {
 	return c
 }
 
-func Init(filepath string, section string) (Config, error) {
+// ConfigSource describes where Init loads the credentials of a section from.
+type ConfigSource struct {
+	// EnvPrefix is the prefix of the environment variables holding the credentials, AKAMAI_
+	// or e.g. AKAMAI_PAPI_, or empty when they are read from the .edgerc file.
+	EnvPrefix string
+
+	// Path and Section locate the credentials when they are read from the .edgerc file.
+	Path    string
+	Section string
+
+	// Reason explains why the source was chosen.
+	Reason string
+
+	// Config holds the credentials loaded from the source, or Err the error loading them.
+	Config Config
+	Err    error
+}
+
+// InitSource returns the source Init loads the credentials of section from, along with the
+// credentials loaded from it. Environment variables take precedence over the .edgerc file:
+// AKAMAI_<SECTION>_HOST selects the AKAMAI_<SECTION>_ variables for any section and AKAMAI_HOST
+// the AKAMAI_ variables for the default section. Other sections fall back to the AKAMAI_
+// variables when the file cannot be loaded.
+func InitSource(filepath string, section string) ConfigSource {
 	if section == "" {
 		section = defaultSection
 	} else {
 		section = strings.ToUpper(section)
 	}
-
-	_, exists := os.LookupEnv("AKAMAI_" + section + "_HOST")
-	if !exists && section == defaultSection {
-		_, exists := os.LookupEnv("AKAMAI_HOST")
-
-		if exists {
-			return InitEnv("")
-		}
+	if filepath == "" {
+		filepath = "~/.edgerc"
 	}
+	source := ConfigSource{Path: filepath, Section: strings.ToLower(section)}
 
-	if exists {
-		return InitEnv(section)
+	if _, ok := os.LookupEnv("AKAMAI_" + section + "_HOST"); ok {
+		source.EnvPrefix = "AKAMAI_" + section + "_"
+		source.Reason = fmt.Sprintf("AKAMAI_%s_HOST is set, environment variables take precedence over %s", section, filepath)
+		source.Config, source.Err = InitEnv(section)
+		return source
 	}
 
-	c, err := InitEdgeRc(filepath, strings.ToLower(section))
+	_, ok := os.LookupEnv("AKAMAI_HOST")
+	if ok && section == defaultSection {
+		source.EnvPrefix = "AKAMAI_"
+		source.Reason = fmt.Sprintf("AKAMAI_HOST is set, environment variables take precedence over %s for the default section", filepath)
+		source.Config, source.Err = InitEnv("")
+		return source
+	}
 
-	if err == nil {
-		return c, nil
+	source.Config, source.Err = InitEdgeRc(filepath, source.Section)
+	if ok && source.Err != nil {
+		source.EnvPrefix = "AKAMAI_"
+		source.Reason = fmt.Sprintf("Section %s cannot be loaded from %s (%s), AKAMAI_HOST is set as a fallback", source.Section, filepath, source.Err)
+		source.Config, source.Err = InitEnv("")
+		return source
 	}
 
-	if section != defaultSection {
-		_, ok := os.LookupEnv("AKAMAI_HOST")
-		if ok {
-			return InitEnv("")
-		}
+	source.Reason = fmt.Sprintf("AKAMAI_%s_HOST is not set", section)
+	if section == defaultSection {
+		source.Reason += " nor AKAMAI_HOST"
 	}
+	return source
+}
 
-	return c, fmt.Errorf("Unable to create instance using environment or .edgerc file")
+// Init loads the credentials of section from environment variables or the .edgerc file at
+// filepath, see InitSource for the precedence.
+func Init(filepath string, section string) (Config, error) {
+	source := InitSource(filepath, section)
+	if source.Err != nil && source.EnvPrefix == "" {
+		return source.Config, fmt.Errorf("Unable to create instance using environment or .edgerc file")
+	}
+	return source.Config, source.Err
 }
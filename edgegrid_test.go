@@ -48,7 +48,7 @@ type Test struct {
 }
 
 func TestMakeEdgeTimeStamp(t *testing.T) {
-	actual := makeEdgeTimeStamp()
+	actual := MakeEdgeTimeStamp()
 	expected := regexp.MustCompile(`^\d{4}[0-1][0-9][0-3][0-9]T[0-2][0-9]:[0-5][0-9]:[0-5][0-9]\+0000$`)
 	if assert.Regexp(t, expected, actual, "Fail: Regex do not match") {
 		t.Log("Pass: Regex matches")
@@ -56,9 +56,9 @@ func TestMakeEdgeTimeStamp(t *testing.T) {
 }
 
 func TestCreateNonce(t *testing.T) {
-	actual := createNonce()
+	actual := CreateNonce()
 	for i := 0; i < 100; i++ {
-		expected := createNonce()
+		expected := CreateNonce()
 		assert.NotEqual(t, actual, expected, "Fail: Nonce matches")
 	}
 }
@@ -105,7 +105,44 @@ func TestAddRequestHeader(t *testing.T) {
 	}
 	actual := AddRequestHeader(config, req)
 	assert.NotEmpty(t, actual.Header.Get("Authorization"))
-	assert.NotEmpty(t, actual.Header.Get("Content-Type"))
+	assert.Equal(t, "application/json", actual.Header.Get("Content-Type"))
+
+	// A Content-Type set by the caller is kept rather than overwritten
+	req, _ = http.NewRequest("PATCH", config.Host, bytes.NewBufferString("[]"))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	actual = config.AddRequestHeader(req)
+	assert.Equal(t, "application/json-patch+json", actual.Header.Get("Content-Type"))
+}
+
+func TestSignRequest(t *testing.T) {
+	req, err := http.NewRequest("GET", "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/", nil)
+	assert.NoError(t, err)
+	expected := config.createAuthHeader(req, timestamp, nonce)
+
+	actual := config.SignRequest(req, timestamp, nonce)
+	assert.Equal(t, expected, actual.Header.Get("Authorization"))
+	assert.Empty(t, actual.Header.Get("Content-Type"))
+}
+
+func TestSigningComponents(t *testing.T) {
+	req, err := http.NewRequest("POST", "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/testapi/v1/t3?a=b", bytes.NewBufferString("body"))
+	assert.NoError(t, err)
+	req.Header.Set("X-Test1", "  Some   Value ")
+	req.Header.Set("X-Other", "unsigned")
+
+	components := config.SigningComponents(req, "EG1-HMAC-SHA256 client_token=x;")
+	assert.Equal(t, []string{
+		"POST",
+		"https",
+		"akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net",
+		"/testapi/v1/t3?a=b",
+		"x-test1:some value",
+		createHash("body"),
+		"EG1-HMAC-SHA256 client_token=x;",
+	}, components)
+
+	body, _ := ioutil.ReadAll(req.Body)
+	assert.Equal(t, "body", string(body))
 }
 
 func TestInitConfigBroken(t *testing.T) {
@@ -344,3 +381,181 @@ func TestInitWithInvalidEdgeRcNotDefault(t *testing.T) {
 	assert.Equal(t, c.MaxBody, 131072)
 	assert.Equal(t, c.HeaderToSign, []string(nil))
 }
+
+func TestInitSource(t *testing.T) {
+	os.Clearenv()
+
+	source := InitSource("sample_edgerc", "")
+	assert.Equal(t, "", source.EnvPrefix)
+	assert.Equal(t, "default", source.Section)
+	assert.Equal(t, "AKAMAI_DEFAULT_HOST is not set nor AKAMAI_HOST", source.Reason)
+	assert.NoError(t, source.Err)
+	assert.Equal(t, "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=", source.Config.ClientSecret)
+	assert.Error(t, InitSource("edgerc_that_doesnt_parse", "").Err)
+
+	os.Setenv("AKAMAI_HOST", "env-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net")
+	assert.Equal(t, "AKAMAI_", InitSource("sample_edgerc", "default").EnvPrefix)
+	assert.Equal(t, "", InitSource("sample_edgerc", "test").EnvPrefix)
+
+	source = InitSource("edgerc_that_doesnt_parse", "test")
+	assert.Equal(t, "AKAMAI_", source.EnvPrefix)
+	assert.Contains(t, source.Reason, "Section test cannot be loaded from edgerc_that_doesnt_parse")
+	assert.Contains(t, source.Err.Error(), "AKAMAI_CLIENT_TOKEN")
+
+	os.Setenv("AKAMAI_TEST_HOST", "testenv-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net")
+	source = InitSource("sample_edgerc", "test")
+	assert.Equal(t, "AKAMAI_TEST_", source.EnvPrefix)
+	assert.Equal(t, "AKAMAI_TEST_HOST is set, environment variables take precedence over sample_edgerc", source.Reason)
+}
+
+func TestVerifyRequest(t *testing.T) {
+	req, _ := http.NewRequest("POST", config.Host+"testapi/v1/t3", bytes.NewBufferString(`{"key":"value"}`))
+	req = config.AddRequestHeader(req)
+
+	assert.NoError(t, config.VerifyRequest(req))
+	body, _ := ioutil.ReadAll(req.Body)
+	assert.Equal(t, `{"key":"value"}`, string(body))
+
+	tampered, _ := http.NewRequest("POST", config.Host+"testapi/v1/t3", bytes.NewBufferString(`{"key":"other"}`))
+	tampered.Header.Set("Authorization", req.Header.Get("Authorization"))
+	tampered.Header.Set("Content-Type", "application/json")
+	assert.EqualError(t, config.VerifyRequest(tampered), "The signature does not match")
+
+	other := config
+	other.ClientToken = "akab-other-token"
+	assert.Error(t, other.VerifyRequest(req))
+
+	unsigned, _ := http.NewRequest("GET", config.Host, nil)
+	assert.Error(t, config.VerifyRequest(unsigned))
+}
+
+func TestSaveEdgeRc(t *testing.T) {
+	dir, err := ioutil.TempDir("", "edgerc")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := dir + "/edgerc"
+	original := "; credentials\n[default]\nhost = old.luna.akamaiapis.net/\nclient-token = old-token\nclient_secret = old-secret\naccess_token = akab-access\n\n[other]\nhost = other.luna.akamaiapis.net/\n"
+	assert.NoError(t, ioutil.WriteFile(path, []byte(original), 0640))
+
+	rotated := Config{Host: "old.luna.akamaiapis.net/", ClientToken: "new-token", ClientSecret: "new-secret", AccessToken: "akab-access", MaxBody: 131072}
+	assert.NoError(t, SaveEdgeRc(path, "", rotated))
+
+	data, _ := ioutil.ReadFile(path)
+	assert.Equal(t, "; credentials\n[default]\nhost = old.luna.akamaiapis.net/\nclient-token = new-token\nclient_secret = new-secret\naccess_token = akab-access\n\n[other]\nhost = other.luna.akamaiapis.net/\n", string(data))
+
+	info, _ := os.Stat(path)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+
+	assert.NoError(t, SaveEdgeRc(path, "other", Config{Host: "other.luna.akamaiapis.net/", ClientToken: "a", ClientSecret: "b", AccessToken: "c", MaxBody: 2048, HeaderToSign: []string{"X-Test1", "X-Test2"}}))
+	assert.NoError(t, SaveEdgeRc(path, "added", rotated))
+
+	other, err := InitEdgeRc(path, "other")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", other.ClientSecret)
+	assert.Equal(t, 2048, other.MaxBody)
+	assert.Equal(t, []string{"X-Test1", "X-Test2"}, other.HeaderToSign)
+
+	added, err := InitEdgeRc(path, "added")
+	assert.NoError(t, err)
+	assert.Equal(t, rotated, added)
+
+	created := dir + "/new_edgerc"
+	assert.NoError(t, SaveEdgeRc(created, "default", rotated))
+	info, _ = os.Stat(created)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// A symlinked file is updated through the link, which is kept
+	link := dir + "/link_edgerc"
+	assert.NoError(t, os.Symlink(created, link))
+	assert.NoError(t, SaveEdgeRc(link, "linked", rotated))
+	info, _ = os.Lstat(link)
+	assert.Equal(t, os.ModeSymlink, info.Mode()&os.ModeSymlink)
+	linked, err := InitEdgeRc(created, "linked")
+	assert.NoError(t, err)
+	assert.Equal(t, rotated, linked)
+}
+
+func TestEdgeRcSections(t *testing.T) {
+	dir, err := ioutil.TempDir("", "edgerc")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := dir + "/edgerc"
+	original := "; credentials\n[default]\nhost = a.luna.akamaiapis.net\n\n[ccu]\nhost = b.luna.akamaiapis.net\nmax-body = 2048\n\n[papi]\nhost = c.luna.akamaiapis.net\n"
+	assert.NoError(t, ioutil.WriteFile(path, []byte(original), 0600))
+
+	sections, err := EdgeRcSections(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"default", "ccu", "papi"}, sections)
+
+	assert.NoError(t, SetEdgeRcValue(path, "ccu", "max_body", "4096"))
+	assert.NoError(t, SetEdgeRcValue(path, "ccu", "account-key", "1-ABC"))
+	assert.NoError(t, RemoveEdgeRc(path, "default"))
+	assert.Error(t, RemoveEdgeRc(path, "default"))
+
+	data, _ := ioutil.ReadFile(path)
+	assert.Equal(t, "; credentials\n[ccu]\nhost = b.luna.akamaiapis.net\nmax-body = 4096\naccount_key = 1-ABC\n\n[papi]\nhost = c.luna.akamaiapis.net\n", string(data))
+
+	assert.NoError(t, RemoveEdgeRc(path, "papi"))
+	data, _ = ioutil.ReadFile(path)
+	assert.Equal(t, "; credentials\n[ccu]\nhost = b.luna.akamaiapis.net\nmax-body = 4096\naccount_key = 1-ABC\n", string(data))
+
+	_, err = EdgeRcSections(dir + "/missing")
+	assert.Error(t, err)
+}
+
+func TestAccountSwitchKey(t *testing.T) {
+	client, err := New(nil, Config{Host: "akab-host.luna.akamaiapis.net", AccountKey: "1-ABCDE:1-2RBL"})
+	assert.NoError(t, err)
+
+	req, err := client.NewRequest("GET", "/papi/v1/groups", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://akab-host.luna.akamaiapis.net/papi/v1/groups?accountSwitchKey=1-ABCDE%3A1-2RBL", req.URL.String())
+
+	req, _ = client.NewRequest("GET", "/papi/v1/properties?contractId=ctr_1&groupId=grp_2", nil)
+	assert.Equal(t, "contractId=ctr_1&groupId=grp_2&accountSwitchKey=1-ABCDE%3A1-2RBL", req.URL.RawQuery)
+
+	req, _ = client.NewRequest("GET", "/papi/v1/groups?accountSwitchKey=1-OTHER", nil)
+	assert.Equal(t, "accountSwitchKey=1-OTHER", req.URL.RawQuery)
+
+	client.AccountSwitchKey = ""
+	req, _ = client.NewRequest("GET", "/papi/v1/groups", nil)
+	assert.Equal(t, "", req.URL.RawQuery)
+}
+
+func TestNewRequestBody(t *testing.T) {
+	client, err := New(nil, Config{Host: "akab-host.luna.akamaiapis.net"})
+	assert.NoError(t, err)
+
+	// A nil body still creates a request without a body
+	req, err := client.NewRequest("GET", "/papi/v1/groups", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, req.Body)
+
+	// An io.Reader is sent as-is and can be replayed on redirects
+	req, err = client.NewRequest("POST", "/ccu/v3/invalidate/url", bytes.NewBufferString("raw"))
+	assert.NoError(t, err)
+	body, _ := ioutil.ReadAll(req.Body)
+	assert.Equal(t, "raw", string(body))
+	assert.Equal(t, int64(3), req.ContentLength)
+	assert.NotNil(t, req.GetBody)
+
+	// Other values are JSON encoded; they used to be dropped
+	req, err = client.NewRequest("POST", "/ccu/v3/invalidate/url", map[string]interface{}{"objects": []string{"/a"}})
+	assert.NoError(t, err)
+	body, _ = ioutil.ReadAll(req.Body)
+	assert.Equal(t, "{\"objects\":[\"/a\"]}\n", string(body))
+
+	// NewJSONRequest passes its encoded body through NewRequest, which used to drop it
+	req, err = client.NewJSONRequest("POST", "/ccu/v3/invalidate/url", map[string]interface{}{"objects": []string{"/b"}})
+	assert.NoError(t, err)
+	body, _ = ioutil.ReadAll(req.Body)
+	assert.Equal(t, "{\"objects\":[\"/b\"]}\n", string(body))
+
+	// The body stays readable after signing
+	req, _ = client.NewRequest("POST", "/ccu/v3/invalidate/url", bytes.NewBufferString("signed"))
+	req = client.Config.AddRequestHeader(req)
+	body, _ = ioutil.ReadAll(req.Body)
+	assert.Equal(t, "signed", string(body))
+}
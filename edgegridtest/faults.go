@@ -0,0 +1,146 @@
+package edgegridtest
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+)
+
+// A Fault replaces or alters the response to a single request. next is the handler
+// that would have served the request without the fault.
+type Fault func(w http.ResponseWriter, r *http.Request, next http.Handler)
+
+// Script registers a sequence of faults for pattern. Each signed request matching pattern
+// consumes the next fault in order; once the script is exhausted requests are served normally.
+// Calling Script again for the same pattern replaces the remaining faults.
+func (s *Server) Script(pattern string, faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.scripts == nil {
+		s.scripts = map[string][]Fault{}
+		s.scriptMux = http.NewServeMux()
+	}
+	if _, registered := s.scripts[pattern]; !registered {
+		s.scriptMux.HandleFunc(pattern, func(http.ResponseWriter, *http.Request) {})
+	}
+	s.scripts[pattern] = append([]Fault(nil), faults...)
+}
+
+// nextFault pops the next scripted fault for r, if any.
+func (s *Server) nextFault(r *http.Request) Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.scriptMux == nil {
+		return nil
+	}
+	_, pattern := s.scriptMux.Handler(r)
+	script := s.scripts[pattern]
+	if len(script) == 0 {
+		return nil
+	}
+	s.scripts[pattern] = script[1:]
+	return script[0]
+}
+
+// Repeat returns a script of n copies of f, e.g. for a burst of server errors.
+func Repeat(n int, f Fault) []Fault {
+	faults := make([]Fault, n)
+	for i := range faults {
+		faults[i] = f
+	}
+	return faults
+}
+
+// Pass serves the request normally. It is useful to interleave successes in a script.
+func Pass() Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		next.ServeHTTP(w, r)
+	}
+}
+
+// RateLimit responds 429 Too Many Requests with a Retry-After header of retryAfter (whole seconds).
+func RateLimit(retryAfter time.Duration) Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter/time.Second)))
+		WriteProblem(w, r, http.StatusTooManyRequests, "Too Many Requests", "Rate limit exceeded")
+	}
+}
+
+// ServerError responds with the given 5xx status and a problem body.
+func ServerError(status int) Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		WriteProblem(w, r, status, http.StatusText(status), "Injected server error")
+	}
+}
+
+// Delay waits for d before serving the request normally. It returns early without
+// responding if the client gives up first.
+func Delay(d time.Duration) Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			next.ServeHTTP(w, r)
+		case <-r.Context().Done():
+		}
+	}
+}
+
+// ResetConnection aborts the connection with a TCP reset without sending a response.
+func ResetConnection() Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		hijacker, ok := w.(http.Hijacker)
+		if !ok {
+			panic(http.ErrAbortHandler)
+		}
+		conn, _, err := hijacker.Hijack()
+		if err != nil {
+			panic(http.ErrAbortHandler)
+		}
+		if tlsConn, ok := conn.(*tls.Conn); ok {
+			conn = tlsConn.NetConn()
+		}
+		if tcpConn, ok := conn.(*net.TCPConn); ok {
+			tcpConn.SetLinger(0)
+		}
+		conn.Close()
+	}
+}
+
+// TruncateBody serves the request normally but sends only the first n bytes of the
+// response body while advertising its full Content-Length, then closes the connection.
+func TruncateBody(n int) Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+
+		body := rec.Body.Bytes()
+		sent := body
+		if n < len(body) {
+			sent = body[:n]
+		}
+
+		for k, v := range rec.Header() {
+			w.Header()[k] = v
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Header().Set("Connection", "close")
+		w.WriteHeader(rec.Code)
+		w.Write(sent)
+	}
+}
+
+// ClockSkew rejects the request the way Akamai does when the request timestamp is
+// too far from the server clock: 401 with a "timestamp invalid" problem body.
+func ClockSkew() Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		WriteProblem(w, r, http.StatusUnauthorized, "Bad request", "Timestamp invalid")
+	}
+}
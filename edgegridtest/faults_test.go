@@ -0,0 +1,98 @@
+package edgegridtest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const locations = "GET /diagnostic-tools/v1/locations"
+
+func newFaultServer() *Server {
+	s := NewServer()
+	s.HandleJSON(locations, http.StatusOK, map[string][]string{"locations": {"Tokyo, Japan"}})
+	return s
+}
+
+func TestScriptSequence(t *testing.T) {
+	s := newFaultServer()
+	defer s.Close()
+
+	s.Script(locations, append(Repeat(2, ServerError(http.StatusServiceUnavailable)), RateLimit(3*time.Second), Pass(), ClockSkew())...)
+
+	client := s.Client()
+	var statuses []int
+	for i := 0; i < 6; i++ {
+		res, err := client.Get("/diagnostic-tools/v1/locations")
+		assert.NoError(t, err)
+		statuses = append(statuses, res.StatusCode)
+
+		if res.StatusCode == http.StatusTooManyRequests {
+			assert.Equal(t, "3", res.Header.Get("Retry-After"))
+		}
+		if i == 4 {
+			problem := Problem{}
+			assert.NoError(t, res.BodyJSON(&problem))
+			assert.Equal(t, "Timestamp invalid", problem.Detail)
+		}
+	}
+
+	assert.Equal(t, []int{503, 503, 429, 200, 401, 200}, statuses)
+}
+
+func TestScriptOnlyAffectsRoute(t *testing.T) {
+	s := newFaultServer()
+	defer s.Close()
+	s.HandleJSON("GET /siteshield/v1/maps", http.StatusOK, []string{})
+
+	s.Script(locations, ServerError(http.StatusBadGateway))
+
+	res, err := s.Client().Get("/siteshield/v1/maps")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	res, err = s.Client().Get("/diagnostic-tools/v1/locations")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, res.StatusCode)
+}
+
+func TestDelay(t *testing.T) {
+	s := newFaultServer()
+	defer s.Close()
+	s.Script(locations, Delay(time.Second))
+
+	client := s.Client()
+	client.Timeout = 50 * time.Millisecond
+	_, err := client.Get("/diagnostic-tools/v1/locations")
+	assert.Error(t, err)
+
+	res, err := client.Get("/diagnostic-tools/v1/locations")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestResetConnection(t *testing.T) {
+	s := newFaultServer()
+	defer s.Close()
+	s.Script(locations, ResetConnection())
+
+	_, err := s.Client().Get("/diagnostic-tools/v1/locations")
+	assert.Error(t, err)
+}
+
+func TestTruncateBody(t *testing.T) {
+	s := newFaultServer()
+	defer s.Close()
+	s.Script(locations, TruncateBody(5))
+
+	res, err := s.Client().Get("/diagnostic-tools/v1/locations")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	body, err := ioutil.ReadAll(res.Body)
+	assert.Error(t, err)
+	assert.Equal(t, `{"loc`, string(body))
+}
@@ -0,0 +1,376 @@
+// Package recorder provides a record-and-replay http.RoundTripper for testing
+// code built on the Akamai {OPEN} EdgeGrid client without network access.
+//
+// In record mode every request is sent through the wrapped transport and the
+// request/response pair is appended to a JSON-lines cassette, with
+// authentication headers scrubbed. In replay mode requests are answered from
+// the cassette, matched by method, path, query and body; a request without a
+// recorded match fails.
+package recorder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode selects whether a Recorder talks to the network or to its cassette.
+type Mode int
+
+const (
+	// ModeReplay serves responses from an existing cassette.
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests to the real transport and writes them to the cassette.
+	ModeRecord
+)
+
+// ScrubHeaders lists the request and response headers that are never written to a cassette.
+var ScrubHeaders = []string{
+	"Authorization",
+	"X-Akamai-ACS-Auth-Data",
+	"X-Akamai-ACS-Auth-Sign",
+}
+
+// ErrNoMatch is returned in replay mode for requests that have no recorded interaction.
+var ErrNoMatch = errors.New("No recorded interaction matches request")
+
+// Request is the recorded form of an http.Request. A body that is not valid UTF-8, such as a
+// NetStorage upload, is kept in BodyBase64 instead of Body so that it is replayed byte for byte.
+type Request struct {
+	Method     string      `json:"method"`
+	Host       string      `json:"host"`
+	Path       string      `json:"path"`
+	Query      string      `json:"query,omitempty"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 []byte      `json:"bodyBase64,omitempty"`
+}
+
+// Response is the recorded form of an http.Response. Its body is kept like the body of a Request.
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 []byte      `json:"bodyBase64,omitempty"`
+}
+
+// splitBody returns body as the Body and BodyBase64 fields of a Request or Response.
+func splitBody(body []byte) (string, []byte) {
+	if utf8.Valid(body) {
+		return string(body), nil
+	}
+	return "", body
+}
+
+func (r Request) body() []byte {
+	if r.BodyBase64 != nil {
+		return r.BodyBase64
+	}
+	return []byte(r.Body)
+}
+
+func (r Response) body() []byte {
+	if r.BodyBase64 != nil {
+		return r.BodyBase64
+	}
+	return []byte(r.Body)
+}
+
+// Interaction is a single line of a cassette.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Recorder is an http.RoundTripper that records to or replays from a cassette file.
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         []bool
+	file         *os.File
+}
+
+// New creates a Recorder for the cassette at path. In record mode the cassette is
+// truncated and requests are sent using transport (http.DefaultTransport if nil).
+// In replay mode the cassette must exist and transport is ignored.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: transport,
+	}
+
+	switch mode {
+	case ModeRecord:
+		if r.transport == nil {
+			r.transport = http.DefaultTransport
+		}
+		file, err := os.Create(path)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to create cassette: %s", err)
+		}
+		r.file = file
+	case ModeReplay:
+		interactions, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.interactions = interactions
+		r.used = make([]bool, len(interactions))
+	default:
+		return nil, fmt.Errorf("Unknown recorder mode: %d", mode)
+	}
+
+	return r, nil
+}
+
+// Load reads all interactions from the cassette at path.
+func Load(path string) ([]*Interaction, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open cassette: %s", err)
+	}
+	defer file.Close()
+
+	var interactions []*Interaction
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		interaction := &Interaction{}
+		if err := json.Unmarshal(scanner.Bytes(), interaction); err != nil {
+			return nil, fmt.Errorf("Unable to parse cassette %s line %d: %s", path, line, err)
+		}
+		interactions = append(interactions, interaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Unable to read cassette: %s", err)
+	}
+
+	return interactions, nil
+}
+
+// Mode returns the mode the Recorder was created with.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an http.Client using the Recorder as its transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, recorded, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	return r.record(req, recorded)
+}
+
+// Unused returns the recorded interactions that have not been replayed yet.
+func (r *Recorder) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []*Interaction
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// Close flushes and closes the cassette when recording. It is a no-op in replay mode.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	interaction := Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Headers:    scrub(res.Header),
+		},
+	}
+	interaction.Response.Body, interaction.Response.BodyBase64 = splitBody(body)
+
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil, errors.New("Cassette is closed")
+	}
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("Unable to write cassette: %s", err)
+	}
+
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.used[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		body := interaction.Response.body()
+		res := &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        cloneHeader(interaction.Response.Headers),
+			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}
+		return res, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoMatch, recorded.Method, concatPathQuery(recorded.Path, recorded.Query))
+}
+
+// newRequest records req. The body is read from a clone of req, which is returned for sending
+// so that the caller's request is left unmodified as http.RoundTripper requires.
+func newRequest(req *http.Request) (*http.Request, Request, error) {
+	recorded := Request{
+		Method:  req.Method,
+		Host:    req.URL.Host,
+		Path:    req.URL.Path,
+		Query:   req.URL.Query().Encode(),
+		Headers: scrub(req.Header),
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, recorded, nil
+	}
+
+	reader := req.Body
+	if req.GetBody != nil {
+		req.Body.Close()
+		var err error
+		if reader, err = req.GetBody(); err != nil {
+			return nil, recorded, err
+		}
+	}
+	body, err := ioutil.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return nil, recorded, err
+	}
+	recorded.Body, recorded.BodyBase64 = splitBody(body)
+
+	clone := req.Clone(req.Context())
+	clone.Body = ioutil.NopCloser(bytes.NewReader(body))
+	clone.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+	return clone, recorded, nil
+}
+
+// matches compares requests by method, path, query and body. JSON bodies are
+// compared after compacting so that formatting differences do not matter.
+func matches(recorded, req Request) bool {
+	if recorded.Method != req.Method || recorded.Path != req.Path {
+		return false
+	}
+	if canonicalQuery(recorded.Query) != canonicalQuery(req.Query) {
+		return false
+	}
+	return canonicalBody(recorded.body()) == canonicalBody(req.body())
+}
+
+func canonicalQuery(query string) string {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return query
+	}
+	return values.Encode()
+}
+
+func canonicalBody(body []byte) string {
+	buf := new(bytes.Buffer)
+	if err := json.Compact(buf, body); err != nil {
+		return string(body)
+	}
+	return buf.String()
+}
+
+func scrub(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+	scrubbed := cloneHeader(header)
+	for _, name := range ScrubHeaders {
+		scrubbed.Del(name)
+	}
+	return scrubbed
+}
+
+func cloneHeader(header http.Header) http.Header {
+	clone := make(http.Header, len(header))
+	for k, v := range header {
+		clone[k] = append([]string(nil), v...)
+	}
+	return clone
+}
+
+func concatPathQuery(path, query string) string {
+	if query == "" {
+		return path
+	}
+	return fmt.Sprintf("%s?%s", path, query)
+}
@@ -0,0 +1,153 @@
+package recorder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/stretchr/testify/assert"
+)
+
+var config = edgegrid.Config{
+	ClientToken:  "akab-client-token-xxx-xxxxxxxxxxxxxxxx",
+	ClientSecret: "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx=",
+	AccessToken:  "akab-access-token-xxx-xxxxxxxxxxxxxxxx",
+	MaxBody:      2048,
+}
+
+func cassette(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "recorder")
+	if err != nil {
+		t.Fatalf("Unable to create temp dir: %s", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "cassette.jsonl")
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := cassette(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"path":%q,"body":%q}`, r.URL.Path, string(body))
+	}))
+	defer server.Close()
+
+	rec, err := New(path, ModeRecord, nil)
+	assert.NoError(t, err)
+
+	c := config
+	c.Host = strings.TrimPrefix(server.URL, "http://")
+
+	req, _ := http.NewRequest("POST", server.URL+"/ccu/v3/invalidate/url/staging?b=2&a=1", strings.NewReader(`{"objects": ["/a"]}`))
+	req = c.AddRequestHeader(req)
+	res, err := rec.Client().Do(req)
+	assert.NoError(t, err)
+	body, _ := ioutil.ReadAll(res.Body)
+	assert.Equal(t, `{"path":"/ccu/v3/invalidate/url/staging","body":"{\"objects\": [\"/a\"]}"}`, string(body))
+	assert.NoError(t, rec.Close())
+
+	raw, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(raw), "EG1-HMAC-SHA256")
+	assert.NotContains(t, string(raw), c.ClientToken)
+
+	server.Close()
+
+	replay, err := New(path, ModeReplay, nil)
+	assert.NoError(t, err)
+
+	req, _ = http.NewRequest("POST", server.URL+"/ccu/v3/invalidate/url/staging?a=1&b=2", strings.NewReader(`{"objects":["/a"]}`))
+	req = c.AddRequestHeader(req)
+	original := req.Body
+	res, err = replay.RoundTrip(req)
+	assert.NoError(t, err)
+	assert.True(t, req.Body == original, "Fail: RoundTrip must not modify the request")
+	assert.Equal(t, 200, res.StatusCode)
+	assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+	replayed, _ := ioutil.ReadAll(res.Body)
+	assert.Equal(t, string(body), string(replayed))
+	assert.Empty(t, replay.Unused())
+}
+
+func TestRecordAndReplayBinary(t *testing.T) {
+	path := cassette(t)
+	upload := []byte{0xff, 0xfe, 0x00, 'a', 0x80}
+	download := []byte{0x89, 'P', 'N', 'G', 0xc3, 0x28}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, upload, body)
+		w.Write(download)
+	}))
+	defer server.Close()
+
+	rec, err := New(path, ModeRecord, nil)
+	assert.NoError(t, err)
+	req, _ := http.NewRequest("PUT", server.URL+"/123/file.png", bytes.NewReader(upload))
+	res, err := rec.RoundTrip(req)
+	assert.NoError(t, err)
+	res.Body.Close()
+	assert.NoError(t, rec.Close())
+	server.Close()
+
+	replay, err := New(path, ModeReplay, nil)
+	assert.NoError(t, err)
+	req, _ = http.NewRequest("PUT", server.URL+"/123/file.png", bytes.NewReader([]byte{0xff, 0xfe, 0x00, 'a', 0x81}))
+	_, err = replay.RoundTrip(req)
+	assert.True(t, errors.Is(err, ErrNoMatch), "Fail: binary bodies must match exactly")
+
+	req, _ = http.NewRequest("PUT", server.URL+"/123/file.png", bytes.NewReader(upload))
+	res, err = replay.RoundTrip(req)
+	assert.NoError(t, err)
+	body, _ := ioutil.ReadAll(res.Body)
+	assert.Equal(t, download, body)
+}
+
+func TestReplayUnmatched(t *testing.T) {
+	path := cassette(t)
+	line := `{"request":{"method":"GET","host":"example.com","path":"/one"},"response":{"statusCode":200,"body":"ok"}}`
+	assert.NoError(t, ioutil.WriteFile(path, []byte(line+"\n"), 0600))
+
+	rec, err := New(path, ModeReplay, nil)
+	assert.NoError(t, err)
+
+	matrix := []struct {
+		method string
+		url    string
+	}{
+		{"POST", "https://example.com/one"},
+		{"GET", "https://example.com/two"},
+		{"GET", "https://example.com/one?x=1"},
+	}
+	for _, tt := range matrix {
+		req, _ := http.NewRequest(tt.method, tt.url, nil)
+		_, err := rec.RoundTrip(req)
+		assert.True(t, errors.Is(err, ErrNoMatch), "Fail: %s %s", tt.method, tt.url)
+	}
+
+	req, _ := http.NewRequest("GET", "https://example.com/one", nil)
+	_, err = rec.RoundTrip(req)
+	assert.NoError(t, err)
+
+	_, err = rec.RoundTrip(req)
+	assert.True(t, errors.Is(err, ErrNoMatch), "Fail: interactions are replayed once")
+}
+
+func TestLoadInvalidCassette(t *testing.T) {
+	path := cassette(t)
+	assert.NoError(t, ioutil.WriteFile(path, []byte("{not json\n"), 0600))
+
+	_, err := New(path, ModeReplay, nil)
+	assert.Error(t, err)
+
+	_, err = New(path+".missing", ModeReplay, nil)
+	assert.Error(t, err)
+}
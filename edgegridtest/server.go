@@ -0,0 +1,220 @@
+// Package edgegridtest provides a local stand-in for the Akamai {OPEN} APIs.
+//
+// A Server verifies the EdgeGrid signature of every request it receives
+// against its credentials before dispatching the request to registered
+// route handlers or canned JSON fixtures, so code using edgegrid.Client can
+// be tested end-to-end, including signing, without network access.
+//
+// Faults such as rate limiting, server errors, slow responses, connection
+// resets, truncated bodies and clock skew rejections can be scripted per
+// route with Server.Script to exercise retry and timeout handling.
+package edgegridtest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// Request is a copy of a request received by a Server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Problem is an HTTP Problem Details body as returned by the Akamai APIs.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// Server is a TLS httptest.Server that only accepts correctly signed EdgeGrid requests.
+type Server struct {
+	// URL of the server, in the form https://127.0.0.1:port
+	URL string
+
+	// Config holds the credentials the server accepts, with Host pointing at the server.
+	Config edgegrid.Config
+
+	server *httptest.Server
+	mux    *http.ServeMux
+
+	mu        sync.Mutex
+	requests  []*Request
+	scripts   map[string][]Fault
+	scriptMux *http.ServeMux
+}
+
+// NewServer starts a Server with randomly generated credentials.
+func NewServer() *Server {
+	return NewServerWithConfig(edgegrid.Config{
+		ClientToken:  "akab-client-token-" + randomToken(12),
+		ClientSecret: randomToken(32),
+		AccessToken:  "akab-access-token-" + randomToken(12),
+		MaxBody:      131072,
+	})
+}
+
+// NewServerWithConfig starts a Server accepting the credentials in config. The Host of config is
+// replaced with the address of the server.
+func NewServerWithConfig(config edgegrid.Config) *Server {
+	s := &Server{
+		mux: http.NewServeMux(),
+	}
+	s.server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+
+	if config.MaxBody == 0 {
+		config.MaxBody = 131072
+	}
+	config.Host = strings.TrimPrefix(s.server.URL, "https://")
+	s.Config = config
+
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// HTTPClient returns an http.Client that trusts the server's certificate.
+func (s *Server) HTTPClient() *http.Client {
+	return s.server.Client()
+}
+
+// Client returns an edgegrid.Client configured to talk to the server.
+func (s *Server) Client() *edgegrid.Client {
+	client, err := edgegrid.New(s.HTTPClient(), s.Config)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// Handle registers handler for pattern, using the http.ServeMux pattern syntax
+// (e.g. "GET /diagnostic-tools/v1/locations").
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// HandleFunc registers a handler function for pattern.
+func (s *Server) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	s.mux.HandleFunc(pattern, handler)
+}
+
+// HandleJSON registers a canned response for pattern: v is encoded as JSON and returned with status.
+func (s *Server) HandleJSON(pattern string, status int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("Unable to encode fixture for %s: %s", pattern, err))
+	}
+	s.HandleRaw(pattern, status, "application/json", body)
+}
+
+// HandleFixture registers the contents of the JSON file at path as the response for pattern.
+func (s *Server) HandleFixture(pattern string, status int, path string) error {
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Unable to read fixture: %s", err)
+	}
+	if !json.Valid(body) {
+		return fmt.Errorf("Fixture %s is not valid JSON", path)
+	}
+	s.HandleRaw(pattern, status, "application/json", body)
+	return nil
+}
+
+// HandleRaw registers a canned response with the given content type for pattern.
+func (s *Server) HandleRaw(pattern string, status int, contentType string, body []byte) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(status)
+		w.Write(body)
+	})
+}
+
+// Requests returns the correctly signed requests received so far, in order.
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Request(nil), s.requests...)
+}
+
+// WriteProblem writes an HTTP Problem Details response, as the Akamai APIs do for errors.
+func WriteProblem(w http.ResponseWriter, r *http.Request, status int, title, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(Problem{
+		Type:     "https://problems.luna.akamaiapis.net/-/pep-authn/request-error",
+		Title:    title,
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+	})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := s.Config.VerifyRequest(r); err != nil {
+		WriteProblem(w, r, http.StatusUnauthorized, "Bad request", err.Error())
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, "Bad request", err.Error())
+		return
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, &Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	s.mu.Unlock()
+
+	if fault := s.nextFault(r); fault != nil {
+		fault(w, r, http.HandlerFunc(s.route))
+		return
+	}
+
+	s.route(w, r)
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	if _, pattern := s.mux.Handler(r); pattern == "" {
+		WriteProblem(w, r, http.StatusNotFound, "Not Found", "No route registered for "+r.Method+" "+r.URL.Path)
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+func randomToken(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
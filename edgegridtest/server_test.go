@@ -0,0 +1,114 @@
+package edgegridtest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type locationsResponse struct {
+	Locations []string `json:"locations"`
+}
+
+func TestServerJSONFixture(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.HandleJSON("GET /diagnostic-tools/v1/locations", http.StatusOK, locationsResponse{
+		Locations: []string{"Auckland, New Zealand", "Boston, MA, United States"},
+	})
+
+	res, err := s.Client().Get("/diagnostic-tools/v1/locations")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	locations := locationsResponse{}
+	assert.NoError(t, res.BodyJSON(&locations))
+	assert.Len(t, locations.Locations, 2)
+
+	requests := s.Requests()
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, "GET", requests[0].Method)
+		assert.Equal(t, "/diagnostic-tools/v1/locations", requests[0].Path)
+	}
+}
+
+func TestServerHandlerReceivesBody(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.HandleFunc("POST /ccu/v3/invalidate/url/staging", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string][]string
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]int{"objects": len(body["objects"])})
+	})
+
+	res, err := s.Client().PostJSON("/ccu/v3/invalidate/url/staging", map[string][]string{"objects": {"/a", "/b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+
+	var result map[string]int
+	assert.NoError(t, res.BodyJSON(&result))
+	assert.Equal(t, 2, result["objects"])
+	assert.JSONEq(t, `{"objects":["/a","/b"]}`, string(s.Requests()[0].Body))
+}
+
+func TestServerRejectsBadSignature(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.HandleJSON("GET /siteshield/v1/maps", http.StatusOK, []string{})
+
+	client := s.Client()
+	client.Config.ClientSecret = "not-the-secret"
+
+	res, err := client.Get("/siteshield/v1/maps")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+	problem := Problem{}
+	assert.NoError(t, res.BodyJSON(&problem))
+	assert.Equal(t, "The signature does not match", problem.Detail)
+	assert.Empty(t, s.Requests())
+
+	req, _ := http.NewRequest("GET", s.URL+"/siteshield/v1/maps", nil)
+	unsigned, err := s.HTTPClient().Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, unsigned.StatusCode)
+}
+
+func TestServerUnknownRoute(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	res, err := s.Client().Get("/papi/v1/groups")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestServerFixtureFile(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	dir, err := ioutil.TempDir("", "edgegridtest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "locations.json")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"locations":["Tokyo, Japan"]}`), 0600))
+	assert.NoError(t, s.HandleFixture("GET /diagnostic-tools/v1/locations", http.StatusOK, path))
+
+	assert.NoError(t, ioutil.WriteFile(path+".bad", []byte(`{`), 0600))
+	assert.Error(t, s.HandleFixture("GET /bad", http.StatusOK, path+".bad"))
+
+	res, err := s.Client().Get("/diagnostic-tools/v1/locations")
+	assert.NoError(t, err)
+	locations := locationsResponse{}
+	assert.NoError(t, res.BodyJSON(&locations))
+	assert.Equal(t, []string{"Tokyo, Japan"}, locations.Locations)
+}
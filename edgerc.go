@@ -0,0 +1,285 @@
+package edgegrid
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/mattes/go-expand-tilde.v1"
+)
+
+// edgeRcValue is a key and value to set in an .edgerc section.
+type edgeRcValue struct {
+	key   string
+	value string
+}
+
+// SaveEdgeRc writes the credentials of c to section of the .edgerc file at path, e.g. after a
+// credential rotation. Existing keys are updated in place and other sections, keys and comments
+// are left untouched; the section and the file are created if missing. path and section default
+// to "~/.edgerc" and "default" like in InitEdgeRc.
+func SaveEdgeRc(path string, section string, c Config) error {
+	return updateEdgeRc(path, section, edgeRcValues(c), false)
+}
+
+// ReplaceEdgeRc writes the credentials of c to section of the .edgerc file at path like SaveEdgeRc,
+// but removes the keys of the section c does not set, e.g. an account_key left from earlier
+// credentials. Comments and other sections are left untouched.
+func ReplaceEdgeRc(path string, section string, c Config) error {
+	return updateEdgeRc(path, section, edgeRcValues(c), true)
+}
+
+// edgeRcValues returns the keys of c to write to an .edgerc section.
+func edgeRcValues(c Config) []edgeRcValue {
+	values := []edgeRcValue{
+		{"host", c.Host},
+		{"client_token", c.ClientToken},
+		{"client_secret", c.ClientSecret},
+		{"access_token", c.AccessToken},
+	}
+	if c.AccountKey != "" {
+		values = append(values, edgeRcValue{"account_key", c.AccountKey})
+	}
+	if c.MaxBody != 0 && c.MaxBody != 131072 {
+		values = append(values, edgeRcValue{"max_body", strconv.Itoa(c.MaxBody)})
+	}
+	if len(c.HeaderToSign) > 0 {
+		values = append(values, edgeRcValue{"headers_to_sign", strings.Join(c.HeaderToSign, ",")})
+	}
+	return values
+}
+
+// EdgeRcSections returns the names of the sections of the .edgerc file at path, in file order.
+// path defaults to "~/.edgerc" like in InitEdgeRc.
+func EdgeRcSections(path string) ([]string, error) {
+	file, err := readEdgeRc(path)
+	if err != nil {
+		return nil, err
+	}
+	if !file.exists {
+		return nil, fmt.Errorf("Fatal error config file: %s does not exist", file.path)
+	}
+
+	var sections []string
+	for _, line := range file.lines {
+		if name, ok := sectionName(line); ok {
+			sections = append(sections, name)
+		}
+	}
+	return sections, nil
+}
+
+// SetEdgeRcValue sets a single key of section in the .edgerc file at path, e.g. "max_body" or
+// "account_key", keeping the rest of the file like SaveEdgeRc does.
+func SetEdgeRcValue(path string, section string, key string, value string) error {
+	return updateEdgeRc(path, section, []edgeRcValue{{normalizeKey(key), value}}, false)
+}
+
+// RemoveEdgeRc removes section and its keys from the .edgerc file at path. Other sections and
+// comments are left untouched.
+func RemoveEdgeRc(path string, section string) error {
+	file, err := readEdgeRc(path)
+	if err != nil {
+		return err
+	}
+	if section == "" {
+		section = "default"
+	}
+
+	start, end := file.section(section)
+	if !file.exists || start < 0 {
+		return fmt.Errorf("Section %s not found in %s", section, file.path)
+	}
+
+	// Drop the blank lines separating the section from the previous one as well.
+	for start > 0 && strings.TrimSpace(file.lines[start-1]) == "" {
+		start--
+	}
+	lines := append(file.lines[:start:start], file.lines[end:]...)
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	return file.write(lines)
+}
+
+// edgeRcFile is an .edgerc file read line by line.
+type edgeRcFile struct {
+	path   string
+	lines  []string
+	mode   os.FileMode
+	exists bool
+}
+
+// readEdgeRc reads the .edgerc file at path, which defaults to "~/.edgerc". A missing file
+// has no lines.
+func readEdgeRc(path string) (*edgeRcFile, error) {
+	if path == "" {
+		path = "~/.edgerc"
+	}
+
+	path, err := tilde.Expand(path)
+	if err != nil {
+		return nil, fmt.Errorf("Fatal could not find home dir from user: %s", err)
+	}
+
+	file := &edgeRcFile{path: path, mode: 0600}
+	data, err := ioutil.ReadFile(path)
+	if err == nil {
+		file.exists = true
+		if info, err := os.Stat(path); err == nil {
+			file.mode = info.Mode().Perm()
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("Fatal error config file: %s", err)
+	}
+
+	if len(data) > 0 {
+		file.lines = strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	}
+	return file, nil
+}
+
+// section returns the line of the header of section and the line ending it, the next header or
+// the end of the file. start is -1 if the section is missing.
+func (f *edgeRcFile) section(section string) (start int, end int) {
+	start, end = -1, len(f.lines)
+	for i, line := range f.lines {
+		name, ok := sectionName(line)
+		if !ok {
+			continue
+		}
+		if start >= 0 {
+			return start, i
+		}
+		if name == section {
+			start = i
+		}
+	}
+	return start, end
+}
+
+// write replaces the file with lines, keeping its permissions.
+func (f *edgeRcFile) write(lines []string) error {
+	data := ""
+	if len(lines) > 0 {
+		data = strings.Join(lines, "\n") + "\n"
+	}
+	return writeFileAtomic(f.path, []byte(data), f.mode)
+}
+
+// updateEdgeRc sets values in section of the .edgerc file at path, editing the file line by
+// line so that its layout is kept. Keys match regardless of dashes or underscores. With replace,
+// the other keys of the section are removed.
+func updateEdgeRc(path string, section string, values []edgeRcValue, replace bool) error {
+	if section == "" {
+		section = "default"
+	}
+
+	file, err := readEdgeRc(path)
+	if err != nil {
+		return err
+	}
+
+	lines := file.lines
+	start, end := file.section(section)
+	if start < 0 {
+		if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "["+section+"]")
+		start, end = len(lines)-1, len(lines)
+	}
+
+	set := map[string]bool{}
+	var body []string
+	for i := start + 1; i < end; i++ {
+		key, ok := lineKey(lines[i])
+		if ok {
+			matched := false
+			for _, v := range values {
+				if normalizeKey(key) == v.key {
+					lines[i] = key + " = " + v.value
+					set[v.key] = true
+					matched = true
+				}
+			}
+			if replace && !matched {
+				continue
+			}
+		}
+		body = append(body, lines[i])
+	}
+	lines = append(lines[:start+1], append(body, lines[end:]...)...)
+	end = start + 1 + len(body)
+
+	// New keys go after the last non-blank line of the section.
+	insert := end
+	for insert > start+1 && strings.TrimSpace(lines[insert-1]) == "" {
+		insert--
+	}
+	var added []string
+	for _, v := range values {
+		if !set[v.key] {
+			added = append(added, v.key+" = "+v.value)
+		}
+	}
+	lines = append(lines[:insert], append(added, lines[insert:]...)...)
+
+	return file.write(lines)
+}
+
+// sectionName returns the name of a "[section]" line.
+func sectionName(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if len(line) < 2 || line[0] != '[' || line[len(line)-1] != ']' {
+		return "", false
+	}
+	return strings.TrimSpace(line[1 : len(line)-1]), true
+}
+
+// lineKey returns the key of a "key = value" line.
+func lineKey(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || trimmed[0] == '#' || trimmed[0] == ';' {
+		return "", false
+	}
+	i := strings.IndexAny(trimmed, "=:")
+	if i < 0 {
+		return "", false
+	}
+	return strings.TrimSpace(trimmed[:i]), true
+}
+
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.Replace(key, "-", "_", -1))
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it over path, so
+// readers never see a partially written file. A symlink at path is followed, so that the file
+// it points to is replaced rather than the link.
+func writeFileAtomic(path string, data []byte, mode os.FileMode) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,50 @@
+package edgegrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Error is returned when an API responds with a non-2xx status. When the response
+// body is an HTTP Problem Details document its fields are decoded.
+type Error struct {
+	StatusCode int    `json:"-"`
+	Type       string `json:"type"`
+	Title      string `json:"title"`
+	Detail     string `json:"detail"`
+	Instance   string `json:"instance"`
+
+	// Body is the raw response body.
+	Body []byte `json:"-"`
+
+	// Header holds the response headers, e.g. Retry-After.
+	Header http.Header `json:"-"`
+}
+
+// NewError creates an Error for a response with the given status, headers and body.
+func NewError(statusCode int, header http.Header, body []byte) *Error {
+	e := &Error{
+		StatusCode: statusCode,
+		Body:       body,
+		Header:     header,
+	}
+	json.Unmarshal(body, e)
+	return e
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("API error %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Title != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Title)
+	}
+	if e.Detail != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Detail)
+	}
+	return msg
+}
+
+// isSuccess reports whether statusCode is in the 2xx range.
+func isSuccess(statusCode int) bool {
+	return statusCode >= 200 && statusCode < 300
+}
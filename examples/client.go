@@ -1,13 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math/rand"
-	"net/url"
 	"time"
 
 	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/diagnostictools"
 )
 
 func random(min int, max int) int {
@@ -17,19 +18,6 @@ func random(min int, max int) int {
 	return random
 }
 
-type LocationsResponse struct {
-	Locations []string `json:"locations"`
-}
-
-type DigResponse struct {
-	Dig struct {
-		Hostname    string `json:"hostname"`
-		QueryType   string `json:"queryType"`
-		Result      string `json:"result"`
-		ErrorString string `json:"errorString"`
-	} `json:"dig"`
-}
-
 func main() {
 	config, err := edgegrid.Init("~/.edgerc", "default")
 	config.Debug = false
@@ -38,39 +26,32 @@ func main() {
 		if err == nil {
 			fmt.Println("Requesting locations that support the diagnostic-tools API.")
 
-			res, err := client.Get("/diagnostic-tools/v1/locations")
-			if err != nil {
-				log.Fatal(err.Error())
-			}
-
-			locationsResponse := LocationsResponse{}
-			res.BodyJSON(&locationsResponse)
+			tools := diagnostictools.New(client)
 
+			locations, err := tools.Locations(context.Background())
 			if err != nil {
 				log.Fatal(err.Error())
 			}
 
-			fmt.Printf("There are %d locations that can run dig in the Akamai Network\n", len(locationsResponse.Locations))
+			fmt.Printf("There are %d locations that can run dig in the Akamai Network\n", len(locations))
 
-			if len(locationsResponse.Locations) == 0 {
+			if len(locations) == 0 {
 				log.Fatal("No locations found")
 			}
 
-			location := locationsResponse.Locations[random(0, len(locationsResponse.Locations))-1]
+			location := locations[random(0, len(locations))-1]
 
 			fmt.Println("We will make our call from " + location)
 
 			fmt.Println("Running dig from " + location)
 
 			client.Timeout = 5 * time.Minute
-			res, err = client.Get("/diagnostic-tools/v1/dig?hostname=developer.akamai.com&location=" + url.QueryEscape(location) + "&queryType=A")
+			dig, err := tools.Dig(context.Background(), "developer.akamai.com", "A", location)
 			if err != nil {
 				log.Fatal(err.Error())
 			}
 
-			digResponse := DigResponse{}
-			res.BodyJSON(&digResponse)
-			fmt.Println(digResponse.Dig.Result)
+			fmt.Println(dig.Result)
 		} else {
 			log.Fatal(err.Error())
 		}
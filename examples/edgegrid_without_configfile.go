@@ -1,14 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"log"
+
 	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
-	"io/ioutil"
-	"net/http"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/siteshield"
 )
 
 func main() {
-	client := http.Client{}
 	config := edgegrid.Config{
 		Host:         "xxxxxx.luna.akamaiapis.net",
 		ClientToken:  "xxxx-xxxxxxxxxxx-xxxxxxxxxxx",
@@ -23,9 +24,21 @@ func main() {
 		Debug: false,
 	}
 
-	req, _ := http.NewRequest("GET", fmt.Sprintf("https://%s/siteshield/v1/maps", config.Host), nil)
-	req = config.AddRequestHeader(req)
-	resp, _ := client.Do(req)
-	byt, _ := ioutil.ReadAll(resp.Body)
-	fmt.Println(string(byt))
+	client, err := edgegrid.New(nil, config)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	maps, err := siteshield.New(client).Maps(context.Background())
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	for _, m := range maps {
+		diff, err := m.Diff()
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Printf("%s: %d to add, %d to remove\n", m.RuleName, len(diff.Added), len(diff.Removed))
+	}
 }
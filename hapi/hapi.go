@@ -0,0 +1,104 @@
+// Package hapi provides a read client for the Akamai Edge Hostnames API (HAPI).
+package hapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// dnsZones are the Akamai DNS zones edge hostnames are created in.
+var dnsZones = []string{
+	"edgesuite.net",
+	"edgekey.net",
+	"akamaized.net",
+	"edgesuite-staging.net",
+	"edgekey-staging.net",
+	"akamaized-staging.net",
+}
+
+// EdgeHostname is an edge hostname, RecordName.DNSZone, e.g. "www.example.com.edgekey.net".
+type EdgeHostname struct {
+	EdgeHostnameID         int      `json:"edgeHostnameId"`
+	RecordName             string   `json:"recordName"`
+	DNSZone                string   `json:"dnsZone"`
+	SecurityType           string   `json:"securityType"`
+	UseDefaultTTL          bool     `json:"useDefaultTtl"`
+	UseDefaultMap          bool     `json:"useDefaultMap"`
+	TTL                    int      `json:"ttl"`
+	Map                    string   `json:"map"`
+	SlotNumber             int      `json:"slotNumber,omitempty"`
+	IPVersionBehavior      string   `json:"ipVersionBehavior"`
+	Comments               string   `json:"comments,omitempty"`
+	SerialNumber           int      `json:"serialNumber"`
+	IsEdgeIPBindingEnabled bool     `json:"isEdgeIPBindingEnabled"`
+	ChinaCDN               ChinaCDN `json:"chinaCdn"`
+}
+
+// Hostname returns the full edge hostname.
+func (e *EdgeHostname) Hostname() string {
+	return e.RecordName + "." + e.DNSZone
+}
+
+// ChinaCDN describes the China CDN settings of an edge hostname.
+type ChinaCDN struct {
+	IsChinaCDN bool `json:"isChinaCdn"`
+}
+
+// Client is an Edge Hostnames API client.
+type Client struct {
+	client *edgegrid.Client
+}
+
+// New creates an Edge Hostnames client sending requests with client.
+func New(client *edgegrid.Client) *Client {
+	return &Client{client: client}
+}
+
+// EdgeHostnames lists the edge hostnames of the account. recordNameSubstring and dnsZone filter
+// the list when not empty.
+func (c *Client) EdgeHostnames(ctx context.Context, recordNameSubstring, dnsZone string) ([]EdgeHostname, error) {
+	query := url.Values{}
+	if recordNameSubstring != "" {
+		query.Set("recordNameSubstring", recordNameSubstring)
+	}
+	if dnsZone != "" {
+		query.Set("dnsZone", dnsZone)
+	}
+
+	res, _, err := edgegrid.GetJSON[struct {
+		EdgeHostnames []EdgeHostname `json:"edgeHostnames"`
+	}](ctx, c.client, "/hapi/v1/edge-hostnames", query)
+	return res.EdgeHostnames, err
+}
+
+// EdgeHostname returns the edge hostname edgeHostnameID.
+func (c *Client) EdgeHostname(ctx context.Context, edgeHostnameID int) (*EdgeHostname, error) {
+	e, _, err := edgegrid.GetJSON[*EdgeHostname](ctx, c.client, fmt.Sprintf("/hapi/v1/edge-hostnames/%d", edgeHostnameID), nil)
+	return e, err
+}
+
+// Lookup returns the edge hostname named hostname, e.g. "www.example.com.edgekey.net".
+func (c *Client) Lookup(ctx context.Context, hostname string) (*EdgeHostname, error) {
+	recordName, dnsZone, err := SplitHostname(hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	e, _, err := edgegrid.GetJSON[*EdgeHostname](ctx, c.client, "/hapi/v1/dns-zones/"+url.PathEscape(dnsZone)+"/edge-hostnames/"+url.PathEscape(recordName), nil)
+	return e, err
+}
+
+// SplitHostname splits an edge hostname into its record name and Akamai DNS zone.
+func SplitHostname(hostname string) (recordName, dnsZone string, err error) {
+	hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+	for _, zone := range dnsZones {
+		if strings.HasSuffix(hostname, "."+zone) {
+			return strings.TrimSuffix(hostname, "."+zone), zone, nil
+		}
+	}
+	return "", "", fmt.Errorf("%s is not in an edge hostname DNS zone", hostname)
+}
@@ -0,0 +1,63 @@
+package hapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/edgegridtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEdgeHostnames(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleRaw("GET /hapi/v1/edge-hostnames", http.StatusOK, "application/json", []byte(`{"edgeHostnames":[{"edgeHostnameId":4558392,"recordName":"www.example.com","dnsZone":"edgekey.net","securityType":"ENHANCED-TLS","useDefaultTtl":true,"ttl":21600,"map":"e;dscb.akamaiedge.net","slotNumber":12345,"ipVersionBehavior":"IPV6_COMPLIANCE","chinaCdn":{"isChinaCdn":false}}]}`))
+	s.HandleRaw("GET /hapi/v1/edge-hostnames/4558392", http.StatusOK, "application/json", []byte(`{"edgeHostnameId":4558392,"recordName":"www.example.com","dnsZone":"edgekey.net"}`))
+	s.HandleRaw("GET /hapi/v1/dns-zones/edgesuite.net/edge-hostnames/static.example.com", http.StatusOK, "application/json", []byte(`{"edgeHostnameId":4558393,"recordName":"static.example.com","dnsZone":"edgesuite.net","securityType":"STANDARD-TLS"}`))
+
+	c := New(s.Client())
+	ctx := context.Background()
+
+	hostnames, err := c.EdgeHostnames(ctx, "example", "edgekey.net")
+	assert.NoError(t, err)
+	if assert.Len(t, hostnames, 1) {
+		assert.Equal(t, "www.example.com.edgekey.net", hostnames[0].Hostname())
+		assert.Equal(t, "ENHANCED-TLS", hostnames[0].SecurityType)
+	}
+	assert.Equal(t, "example", s.Requests()[0].Query.Get("recordNameSubstring"))
+	assert.Equal(t, "edgekey.net", s.Requests()[0].Query.Get("dnsZone"))
+
+	hostname, err := c.EdgeHostname(ctx, 4558392)
+	assert.NoError(t, err)
+	assert.Equal(t, "www.example.com", hostname.RecordName)
+
+	hostname, err = c.Lookup(ctx, "Static.Example.com.edgesuite.net.")
+	assert.NoError(t, err)
+	assert.Equal(t, 4558393, hostname.EdgeHostnameID)
+
+	_, err = c.Lookup(ctx, "missing.example.com.edgesuite.net")
+	assert.IsType(t, &edgegrid.Error{}, err)
+}
+
+func TestSplitHostname(t *testing.T) {
+	matrix := []struct {
+		hostname   string
+		recordName string
+		dnsZone    string
+	}{
+		{"www.example.com.edgekey.net", "www.example.com", "edgekey.net"},
+		{"www.example.com.edgesuite-staging.net", "www.example.com", "edgesuite-staging.net"},
+		{"a.akamaized.net", "a", "akamaized.net"},
+	}
+	for _, tt := range matrix {
+		recordName, dnsZone, err := SplitHostname(tt.hostname)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.recordName, recordName)
+		assert.Equal(t, tt.dnsZone, dnsZone)
+	}
+
+	_, _, err := SplitHostname("www.example.com")
+	assert.Error(t, err)
+}
@@ -0,0 +1,198 @@
+// Package iam provides a client for the Akamai Identity and Access Management API, limited to
+// the API client making the requests ("self"): its API access and its credentials.
+//
+// It lets tooling check what the credential in use may do, warn before it expires and rotate
+// it. Rotate creates a new credential and switches the client to it; edgegrid.SaveEdgeRc writes
+// the new secret back to the .edgerc file.
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// Credential statuses.
+const (
+	Active   = "ACTIVE"
+	Inactive = "INACTIVE"
+	Deleted  = "DELETED"
+)
+
+// ErrCredentialNotFound is returned when the client token in use matches no credential of the API client.
+var ErrCredentialNotFound = errors.New("Credential not found for client token")
+
+// APIClient is the API client making the requests.
+type APIClient struct {
+	ClientID          string    `json:"clientId"`
+	ClientName        string    `json:"clientName"`
+	ClientDescription string    `json:"clientDescription"`
+	ClientType        string    `json:"clientType"`
+	AccessToken       string    `json:"accessToken"`
+	ActiveCredentials int       `json:"activeCredentialCount"`
+	CreatedBy         string    `json:"createdBy"`
+	CreatedDate       time.Time `json:"createdDate"`
+	APIAccess         APIAccess `json:"apiAccess"`
+}
+
+// APIAccess lists the APIs an API client may call.
+type APIAccess struct {
+	AllAccessibleAPIs bool  `json:"allAccessibleApis"`
+	APIs              []API `json:"apis"`
+}
+
+// API is an API an API client may call, with its access level, e.g. "READ-ONLY" or "READ-WRITE".
+type API struct {
+	APIID       int    `json:"apiId"`
+	APIName     string `json:"apiName"`
+	Endpoint    string `json:"endpoint"`
+	AccessLevel string `json:"accessLevel"`
+}
+
+// Credential is a credential (client token and secret) of the API client.
+type Credential struct {
+	CredentialID int       `json:"credentialId"`
+	ClientToken  string    `json:"clientToken"`
+	ClientSecret string    `json:"clientSecret,omitempty"`
+	Status       string    `json:"status"`
+	Description  string    `json:"description"`
+	CreatedOn    time.Time `json:"createdOn"`
+	ExpiresOn    time.Time `json:"expiresOn"`
+}
+
+// ExpiresWithin reports whether the credential expires in less than d from now.
+func (c *Credential) ExpiresWithin(d time.Duration) bool {
+	return time.Until(c.ExpiresOn) < d
+}
+
+// CredentialUpdate holds the editable fields of a credential.
+type CredentialUpdate struct {
+	Status      string    `json:"status"`
+	ExpiresOn   time.Time `json:"expiresOn"`
+	Description string    `json:"description,omitempty"`
+}
+
+// Client is an Identity and Access Management API client.
+type Client struct {
+	client *edgegrid.Client
+}
+
+// New creates an Identity and Access Management client sending requests with client.
+func New(client *edgegrid.Client) *Client {
+	return &Client{client: client}
+}
+
+// Self returns the API client making the requests, including the APIs it may call.
+func (c *Client) Self(ctx context.Context) (*APIClient, error) {
+	self, _, err := edgegrid.GetJSON[*APIClient](ctx, c.client, "/identity-management/v3/api-clients/self?apiAccess=true", nil)
+	return self, err
+}
+
+// Credentials lists the credentials of the API client.
+func (c *Client) Credentials(ctx context.Context) ([]Credential, error) {
+	credentials, _, err := edgegrid.GetJSON[[]Credential](ctx, c.client, "/identity-management/v3/api-clients/self/credentials", nil)
+	return credentials, err
+}
+
+// Credential returns the credential credentialID.
+func (c *Client) Credential(ctx context.Context, credentialID int) (*Credential, error) {
+	credential, _, err := edgegrid.GetJSON[*Credential](ctx, c.client, credentialPath(credentialID), nil)
+	return credential, err
+}
+
+// Current returns the credential the client signs its requests with.
+func (c *Client) Current(ctx context.Context) (*Credential, error) {
+	credentials, err := c.Credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range credentials {
+		if credentials[i].ClientToken == c.client.Config.ClientToken {
+			return &credentials[i], nil
+		}
+	}
+	return nil, ErrCredentialNotFound
+}
+
+// CreateCredential creates a credential. The returned credential is the only one to include
+// the client secret.
+func (c *Client) CreateCredential(ctx context.Context) (*Credential, error) {
+	credential, _, err := edgegrid.SendJSON[*struct{}, *Credential](ctx, c.client, "POST", "/identity-management/v3/api-clients/self/credentials", nil)
+	return credential, err
+}
+
+// UpdateCredential updates the status, expiry date and description of the credential credentialID.
+func (c *Client) UpdateCredential(ctx context.Context, credentialID int, update CredentialUpdate) (*Credential, error) {
+	credential, _, err := edgegrid.SendJSON[CredentialUpdate, *Credential](ctx, c.client, "PUT", credentialPath(credentialID), update)
+	return credential, err
+}
+
+// Activate activates the credential credentialID.
+func (c *Client) Activate(ctx context.Context, credentialID int) (*Credential, error) {
+	return c.setStatus(ctx, credentialID, Active)
+}
+
+// Deactivate deactivates the credential credentialID. Requests signed with it fail from then on.
+func (c *Client) Deactivate(ctx context.Context, credentialID int) error {
+	_, _, err := edgegrid.SendJSON[*struct{}, struct{}](ctx, c.client, "POST", credentialPath(credentialID)+"/deactivate", nil)
+	return err
+}
+
+// DeleteCredential deletes the credential credentialID. Only inactive credentials can be deleted.
+func (c *Client) DeleteCredential(ctx context.Context, credentialID int) error {
+	_, _, err := edgegrid.SendJSON[*struct{}, struct{}](ctx, c.client, "DELETE", credentialPath(credentialID), nil)
+	return err
+}
+
+// Rotate creates a credential to replace the one in use, switches the client's Config to it and,
+// if deactivateOld is set, deactivates the old credential using the new one. It returns the new
+// Config and credential; save the Config with edgegrid.SaveEdgeRc, as the client secret cannot be
+// retrieved again.
+func (c *Client) Rotate(ctx context.Context, deactivateOld bool) (edgegrid.Config, *Credential, error) {
+	old, err := c.Current(ctx)
+	if err != nil {
+		return c.client.Config, nil, err
+	}
+
+	credential, err := c.CreateCredential(ctx)
+	if err != nil {
+		return c.client.Config, nil, err
+	}
+	if credential.ClientToken == "" || credential.ClientSecret == "" {
+		return c.client.Config, credential, fmt.Errorf("Credential %d was created without a client token or secret", credential.CredentialID)
+	}
+
+	config := c.client.Config
+	config.ClientToken = credential.ClientToken
+	config.ClientSecret = credential.ClientSecret
+	c.client.Config = config
+
+	if deactivateOld {
+		if err := c.Deactivate(ctx, old.CredentialID); err != nil {
+			return config, credential, fmt.Errorf("Unable to deactivate credential %d: %w", old.CredentialID, err)
+		}
+	}
+
+	return config, credential, nil
+}
+
+func (c *Client) setStatus(ctx context.Context, credentialID int, status string) (*Credential, error) {
+	credential, err := c.Credential(ctx, credentialID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.UpdateCredential(ctx, credentialID, CredentialUpdate{
+		Status:      status,
+		ExpiresOn:   credential.ExpiresOn,
+		Description: credential.Description,
+	})
+}
+
+func credentialPath(credentialID int) string {
+	return fmt.Sprintf("/identity-management/v3/api-clients/self/credentials/%d", credentialID)
+}
@@ -0,0 +1,117 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/edgegridtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newServer starts a server listing two credentials: an inactive one and, unless unknown is
+// set, the one the server's client signs with.
+func newServer(t *testing.T, expiresOn time.Time, unknown bool) *edgegridtest.Server {
+	s := edgegridtest.NewServer()
+	token := s.Config.ClientToken
+	if unknown {
+		token = "akab-unknown"
+	}
+	credentials := []Credential{
+		{CredentialID: 1, ClientToken: "akab-other", Status: Inactive, ExpiresOn: expiresOn.AddDate(-1, 0, 0)},
+		{CredentialID: 2, ClientToken: token, Status: Active, Description: "ci", ExpiresOn: expiresOn},
+	}
+
+	s.HandleFunc("GET /identity-management/v3/api-clients/self/credentials", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(credentials)
+	})
+	s.HandleFunc("GET /identity-management/v3/api-clients/self/credentials/{id}", func(w http.ResponseWriter, r *http.Request) {
+		for _, credential := range credentials {
+			if fmt.Sprint(credential.CredentialID) == r.PathValue("id") {
+				json.NewEncoder(w).Encode(credential)
+				return
+			}
+		}
+		edgegridtest.WriteProblem(w, r, http.StatusNotFound, "Not Found", "")
+	})
+	s.HandleFunc("PUT /identity-management/v3/api-clients/self/credentials/{id}", func(w http.ResponseWriter, r *http.Request) {
+		update := CredentialUpdate{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+		json.NewEncoder(w).Encode(Credential{CredentialID: 1, Status: update.Status, ExpiresOn: update.ExpiresOn, Description: update.Description})
+	})
+	s.HandleFunc("POST /identity-management/v3/api-clients/self/credentials", func(w http.ResponseWriter, r *http.Request) {
+		created := Credential{CredentialID: 3, ClientToken: "akab-new-token", ClientSecret: "new-secret=", Status: Active, ExpiresOn: expiresOn.AddDate(2, 0, 0)}
+		credentials = append(credentials, created)
+		// The new credential is usable right away.
+		s.Config.ClientToken = created.ClientToken
+		s.Config.ClientSecret = created.ClientSecret
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(created)
+	})
+	s.HandleFunc("POST /identity-management/v3/api-clients/self/credentials/{id}/deactivate", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s.HandleRaw("GET /identity-management/v3/api-clients/self", http.StatusOK, "application/json", []byte(`{"clientId":"abcd1234","clientName":"ci","accessToken":"akab-access","apiAccess":{"allAccessibleApis":false,"apis":[{"apiId":5580,"apiName":"Fast Purge","accessLevel":"READ-WRITE"}]}}`))
+
+	return s
+}
+
+func TestSelfAndCurrent(t *testing.T) {
+	expiresOn := time.Now().Add(10 * 24 * time.Hour).UTC().Truncate(time.Second)
+	s := newServer(t, expiresOn, false)
+	defer s.Close()
+	c := New(s.Client())
+	ctx := context.Background()
+
+	self, err := c.Self(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "READ-WRITE", self.APIAccess.APIs[0].AccessLevel)
+	assert.Equal(t, "true", s.Requests()[0].Query.Get("apiAccess"))
+
+	current, err := c.Current(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, current.CredentialID)
+	assert.True(t, expiresOn.Equal(current.ExpiresOn))
+	assert.True(t, current.ExpiresWithin(30*24*time.Hour))
+	assert.False(t, current.ExpiresWithin(24*time.Hour))
+}
+
+func TestActivate(t *testing.T) {
+	s := newServer(t, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), false)
+	defer s.Close()
+
+	credential, err := New(s.Client()).Activate(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, Active, credential.Status)
+	assert.JSONEq(t, `{"status":"ACTIVE","expiresOn":"2029-01-01T00:00:00Z"}`, string(s.Requests()[1].Body))
+}
+
+func TestRotate(t *testing.T) {
+	s := newServer(t, time.Now().Add(24*time.Hour), false)
+	defer s.Close()
+	client := s.Client()
+	c := New(client)
+
+	config, credential, err := c.Rotate(context.Background(), true)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, credential.CredentialID)
+	assert.Equal(t, "akab-new-token", config.ClientToken)
+	assert.Equal(t, "new-secret=", config.ClientSecret)
+	assert.Equal(t, config, client.Config)
+
+	last := s.Requests()[len(s.Requests())-1]
+	assert.Equal(t, "/identity-management/v3/api-clients/self/credentials/2/deactivate", last.Path)
+}
+
+func TestRotateUnknownCredential(t *testing.T) {
+	s := newServer(t, time.Now(), true)
+	defer s.Close()
+
+	_, _, err := New(s.Client()).Rotate(context.Background(), true)
+	assert.True(t, errors.Is(err, ErrCredentialNotFound))
+	assert.Len(t, s.Requests(), 1)
+}
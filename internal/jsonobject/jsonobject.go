@@ -0,0 +1,107 @@
+// Package jsonobject keeps track of the fields of a JSON object that a struct does not model, so
+// that an API document decoded into the struct, modified and encoded again loses nothing.
+package jsonobject
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Meta remembers which modeled fields were present in a decoded object and keeps the others.
+// Embed it, unexported, in a struct and call Unmarshal and Marshal from its JSON methods with an
+// alias type of the struct, which has no JSON methods of its own.
+type Meta struct {
+	present map[string]bool
+	extra   map[string]json.RawMessage
+}
+
+// Unmarshal decodes data into v, a pointer to a struct, and records the fields of data.
+func (m *Meta) Unmarshal(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	known := jsonFields(reflect.TypeOf(v).Elem())
+	m.present = map[string]bool{}
+	m.extra = nil
+	for name, raw := range fields {
+		if known[name] {
+			m.present[name] = true
+			continue
+		}
+		if m.extra == nil {
+			m.extra = map[string]json.RawMessage{}
+		}
+		m.extra[name] = raw
+	}
+
+	return nil
+}
+
+// Marshal encodes v, a pointer to a struct. Modeled fields with zero values are left out unless
+// they were present when decoded or are listed in always; the fields the struct does not model
+// are written back.
+func (m Meta) Marshal(v interface{}, always ...string) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	keep := map[string]bool{}
+	for _, name := range always {
+		keep[name] = true
+	}
+	for name, raw := range fields {
+		if !m.present[name] && !keep[name] && isZero(raw) {
+			delete(fields, name)
+		}
+	}
+	for name, raw := range m.extra {
+		fields[name] = raw
+	}
+
+	return json.Marshal(fields)
+}
+
+// Extra returns the decoded fields the struct does not model.
+func (m Meta) Extra() map[string]json.RawMessage {
+	return m.extra
+}
+
+func jsonFields(t reflect.Type) map[string]bool {
+	fields := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = true
+	}
+	return fields
+}
+
+func isZero(raw json.RawMessage) bool {
+	switch string(raw) {
+	case "null", `""`, "[]", "{}", "false", "0":
+		return true
+	}
+	return false
+}
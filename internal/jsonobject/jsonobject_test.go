@@ -0,0 +1,49 @@
+package jsonobject
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type policy struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Count   int    `json:"count"`
+	Ignored string `json:"-"`
+
+	meta Meta
+}
+
+type policyAlias policy
+
+func (p *policy) UnmarshalJSON(data []byte) error {
+	return p.meta.Unmarshal(data, (*policyAlias)(p))
+}
+
+func (p policy) MarshalJSON() ([]byte, error) {
+	return p.meta.Marshal((*policyAlias)(&p), "name")
+}
+
+func TestRoundTrip(t *testing.T) {
+	var p policy
+	assert.NoError(t, json.Unmarshal([]byte(`{"id":"p1","enabled":false,"future":{"a":[1,2]},"note":"kept"}`), &p))
+	assert.Equal(t, "p1", p.ID)
+	assert.Equal(t, map[string]json.RawMessage{"future": json.RawMessage(`{"a":[1,2]}`), "note": json.RawMessage(`"kept"`)}, p.meta.Extra())
+
+	// Unknown fields and present zero values are written back, absent zero values are left out
+	// unless always listed
+	p.ID = "p2"
+	data, err := json.Marshal(p)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":"p2","name":"","enabled":false,"future":{"a":[1,2]},"note":"kept"}`, string(data))
+
+	// A value that was never decoded has no extra fields
+	data, err = json.Marshal(policy{ID: "p3", Count: 2})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":"p3","name":"","count":2}`, string(data))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"id":1}`), &p))
+}
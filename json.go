@@ -0,0 +1,143 @@
+package edgegrid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// GetJSON sends a signed GET request for path, a URL relative to the Client's BaseURL, with query
+// added to its query string, and decodes the JSON response into a T.
+//
+// Responses with a non-2xx status are returned as an *Error. The response body is always read and
+// closed; the returned Response can still be inspected and its Body re-read.
+func GetJSON[T any](ctx context.Context, client *Client, path string, query url.Values) (T, *Response, error) {
+	var result T
+
+	path, err := WithQuery(path, query)
+	if err != nil {
+		return result, nil, err
+	}
+	req, err := client.NewRequest("GET", path, nil)
+	if err != nil {
+		return result, nil, err
+	}
+
+	return decodeJSON[T](ctx, client, req)
+}
+
+// SendJSON encodes body as JSON and sends it as a signed request with method to path, decoding the
+// JSON response into a Resp. A nil body sends no request body.
+//
+// Responses with a non-2xx status are returned as an *Error. The response body is always read and
+// closed; the returned Response can still be inspected and its Body re-read.
+func SendJSON[Req, Resp any](ctx context.Context, client *Client, method string, path string, body Req) (Resp, *Response, error) {
+	var (
+		result Resp
+		req    *http.Request
+		err    error
+	)
+
+	if isNil(body) {
+		req, err = client.NewRequest(method, path, nil)
+	} else {
+		req, err = client.NewJSONRequest(method, path, body)
+	}
+	if err != nil {
+		return result, nil, err
+	}
+
+	return decodeJSON[Resp](ctx, client, req)
+}
+
+// DoJSON sends req, a request built with the Client, and decodes the JSON response into a T. It
+// is useful when a request needs headers or a body GetJSON and SendJSON do not provide. An Accept
+// header of application/json is added if req has none.
+//
+// Responses with a non-2xx status are returned as an *Error. The response body is always read and
+// closed; the returned Response can still be inspected and its Body re-read.
+func DoJSON[T any](ctx context.Context, client *Client, req *http.Request) (T, *Response, error) {
+	return decodeJSON[T](ctx, client, req)
+}
+
+func decodeJSON[T any](ctx context.Context, client *Client, req *http.Request) (T, *Response, error) {
+	var result T
+
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	res, body, err := client.Send(ctx, req)
+	if err != nil {
+		return result, res, err
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return result, res, nil
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return result, res, err
+	}
+
+	return result, res, nil
+}
+
+// Send signs and sends req with ctx, reads and closes the response body and returns it. Responses
+// with a non-2xx status are returned as an *Error. The Body of the returned Response is replaced by
+// a reader over the buffered body. Send suits endpoints that do not return JSON, see DoJSON.
+func (c *Client) Send(ctx context.Context, req *http.Request) (*Response, []byte, error) {
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+
+	res, err := c.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := res.Bytes()
+	if err != nil {
+		return res, nil, err
+	}
+
+	if !isSuccess(res.StatusCode) {
+		return res, body, NewError(res.StatusCode, res.Header, body)
+	}
+
+	return res, body, nil
+}
+
+// WithQuery returns path with the values of query added to its query string. An error is returned
+// when path cannot be parsed, rather than sending the request without query.
+func WithQuery(path string, query url.Values) (string, error) {
+	if len(query) == 0 {
+		return path, nil
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", fmt.Errorf("Unable to add query to %q: %w", path, err)
+	}
+	q := u.Query()
+	for k, v := range query {
+		for _, value := range v {
+			q.Add(k, value)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
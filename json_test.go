@@ -0,0 +1,127 @@
+package edgegrid_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/edgegridtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type digResponse struct {
+	Dig struct {
+		Hostname  string `json:"hostname"`
+		QueryType string `json:"queryType"`
+	} `json:"dig"`
+}
+
+type purgeRequest struct {
+	Objects []string `json:"objects"`
+}
+
+type purgeResponse struct {
+	PurgeID          string `json:"purgeId"`
+	EstimatedSeconds int    `json:"estimatedSeconds"`
+}
+
+func TestGetJSON(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleFunc("GET /diagnostic-tools/v1/dig", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"dig": map[string]string{
+				"hostname":  r.URL.Query().Get("hostname"),
+				"queryType": r.URL.Query().Get("queryType"),
+			},
+		})
+	})
+
+	query := url.Values{"hostname": {"developer.akamai.com"}}
+	dig, res, err := edgegrid.GetJSON[digResponse](context.Background(), s.Client(), "/diagnostic-tools/v1/dig?queryType=A", query)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "developer.akamai.com", dig.Dig.Hostname)
+	assert.Equal(t, "A", dig.Dig.QueryType)
+
+	body, err := ioutil.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), "developer.akamai.com")
+}
+
+func TestWithQuery(t *testing.T) {
+	path, err := edgegrid.WithQuery("/papi/v1/groups?contractId=ctr_1", url.Values{"groupId": {"grp_1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "/papi/v1/groups?contractId=ctr_1&groupId=grp_1", path)
+
+	_, err = edgegrid.WithQuery("/papi/v1/%zz", url.Values{"groupId": {"grp_1"}})
+	assert.Error(t, err)
+
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	_, _, err = edgegrid.GetJSON[digResponse](context.Background(), s.Client(), "/diagnostic-tools/v1/%zz", url.Values{"hostname": {"a"}})
+	assert.Error(t, err)
+	assert.Empty(t, s.Requests())
+}
+
+func TestGetJSONError(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+
+	_, res, err := edgegrid.GetJSON[digResponse](context.Background(), s.Client(), "/diagnostic-tools/v1/dig", nil)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	if assert.IsType(t, &edgegrid.Error{}, err) {
+		apiErr := err.(*edgegrid.Error)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		assert.Equal(t, "Not Found", apiErr.Title)
+		assert.Equal(t, "API error 404 Not Found: Not Found: No route registered for GET /diagnostic-tools/v1/dig", apiErr.Error())
+	}
+}
+
+func TestSendJSON(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleFunc("POST /ccu/v3/invalidate/url/production", func(w http.ResponseWriter, r *http.Request) {
+		request := purgeRequest{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(purgeResponse{PurgeID: "abc", EstimatedSeconds: len(request.Objects)})
+	})
+	s.HandleRaw("DELETE /items/1", http.StatusNoContent, "application/json", nil)
+
+	purge, res, err := edgegrid.SendJSON[purgeRequest, purgeResponse](context.Background(), s.Client(), "POST", "/ccu/v3/invalidate/url/production", purgeRequest{Objects: []string{"/a", "/b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	assert.Equal(t, purgeResponse{PurgeID: "abc", EstimatedSeconds: 2}, purge)
+
+	_, res, err = edgegrid.SendJSON[*purgeRequest, *purgeResponse](context.Background(), s.Client(), "DELETE", "/items/1", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	assert.Empty(t, s.Requests()[1].Body)
+}
+
+func TestDoJSONAccept(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleFunc("GET /papi/v1/rules", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"accept": r.Header.Get("Accept")})
+	})
+	client := s.Client()
+
+	req, _ := client.NewRequest("GET", "/papi/v1/rules", nil)
+	result, _, err := edgegrid.DoJSON[map[string]string](context.Background(), client, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", result["accept"])
+
+	// An Accept header set by the caller is kept
+	req, _ = client.NewRequest("GET", "/papi/v1/rules", nil)
+	req.Header.Set("Accept", "application/vnd.akamai.papirules.latest+json")
+	result, _, err = edgegrid.DoJSON[map[string]string](context.Background(), client, req)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/vnd.akamai.papirules.latest+json", result["accept"])
+}
@@ -0,0 +1,245 @@
+// Package netstorage provides a client for the Akamai NetStorage HTTP API.
+//
+// NetStorage does not use EdgeGrid authentication: requests are signed with
+// the ACS scheme by Transport, an http.RoundTripper, using an upload account
+// key name and key. File contents are streamed in both directions.
+package netstorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// File is an entry of a stat or dir response.
+type File struct {
+	// Type is "file", "dir" or "symlink".
+	Type   string `xml:"type,attr"`
+	Name   string `xml:"name,attr"`
+	Size   int64  `xml:"size,attr,omitempty"`
+	MD5    string `xml:"md5,attr,omitempty"`
+	SHA256 string `xml:"sha256,attr,omitempty"`
+	MTime  int64  `xml:"mtime,attr"`
+	Target string `xml:"target,attr,omitempty"`
+}
+
+// ModTime returns the modification time of the entry.
+func (f *File) ModTime() time.Time {
+	return time.Unix(f.MTime, 0)
+}
+
+// IsDir reports whether the entry is a directory.
+func (f *File) IsDir() bool {
+	return f.Type == "dir"
+}
+
+type statResponse struct {
+	Directory string `xml:"directory,attr"`
+	Files     []File `xml:"file"`
+}
+
+// UploadOption sets optional parameters of an upload.
+type UploadOption func(url.Values)
+
+// MTime sets the modification time of the uploaded file.
+func MTime(t time.Time) UploadOption {
+	return func(v url.Values) {
+		v.Set("mtime", strconv.FormatInt(t.Unix(), 10))
+	}
+}
+
+// SHA256 makes NetStorage reject the upload unless the content has the given hex SHA-256 digest.
+func SHA256(sum string) UploadOption {
+	return func(v url.Values) {
+		v.Set("sha256", sum)
+	}
+}
+
+// Client is a NetStorage HTTP API client. Paths start with the CP code of the storage group,
+// e.g. "/123456/dir/file.txt".
+type Client struct {
+	// HTTPClient sends the requests; its Transport must sign them, see Transport.
+	HTTPClient *http.Client
+
+	// Host is the NetStorage HTTP API hostname, e.g. "example-nsu.akamaihd.net".
+	Host string
+
+	// Scheme is "https" unless set.
+	Scheme string
+}
+
+// New creates a NetStorage client for host signing requests with keyName and key.
+func New(host, keyName, key string) *Client {
+	return &Client{
+		HTTPClient: &http.Client{Transport: &Transport{KeyName: keyName, Key: key}},
+		Host:       host,
+	}
+}
+
+// Upload uploads the contents of r to the file at path, replacing it if it exists. r is streamed;
+// its length is sent when known.
+func (c *Client) Upload(ctx context.Context, path string, r io.Reader, opts ...UploadOption) error {
+	params := url.Values{}
+	for _, opt := range opts {
+		opt(params)
+	}
+
+	res, err := c.do(ctx, "PUT", path, "upload", params, r)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// Download returns the contents of the file at path. The caller must close it.
+func (c *Client) Download(ctx context.Context, path string) (io.ReadCloser, error) {
+	res, err := c.do(ctx, "GET", path, "download", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return res.Body, nil
+}
+
+// Stat returns the entry at path.
+func (c *Client) Stat(ctx context.Context, path string) (*File, error) {
+	stat, err := c.list(ctx, path, "stat")
+	if err != nil {
+		return nil, err
+	}
+	if len(stat.Files) == 0 {
+		return nil, fmt.Errorf("No entry for %s in stat response", path)
+	}
+	return &stat.Files[0], nil
+}
+
+// Dir lists the entries of the directory at path.
+func (c *Client) Dir(ctx context.Context, path string) ([]File, error) {
+	stat, err := c.list(ctx, path, "dir")
+	if err != nil {
+		return nil, err
+	}
+	return stat.Files, nil
+}
+
+// Mkdir creates the directory at path.
+func (c *Client) Mkdir(ctx context.Context, path string) error {
+	return c.action(ctx, "PUT", path, "mkdir", nil)
+}
+
+// Rmdir deletes the empty directory at path.
+func (c *Client) Rmdir(ctx context.Context, path string) error {
+	return c.action(ctx, "POST", path, "rmdir", nil)
+}
+
+// Delete deletes the file or symlink at path.
+func (c *Client) Delete(ctx context.Context, path string) error {
+	return c.action(ctx, "POST", path, "delete", nil)
+}
+
+// Rename moves the file at path to destination, in the same storage group.
+func (c *Client) Rename(ctx context.Context, path, destination string) error {
+	return c.action(ctx, "POST", path, "rename", url.Values{"destination": {destination}})
+}
+
+// Symlink creates a symbolic link at path pointing to target.
+func (c *Client) Symlink(ctx context.Context, path, target string) error {
+	return c.action(ctx, "POST", path, "symlink", url.Values{"target": {target}})
+}
+
+// SetMTime changes the modification time of the file at path.
+func (c *Client) SetMTime(ctx context.Context, path string, mtime time.Time) error {
+	return c.action(ctx, "POST", path, "mtime", url.Values{"mtime": {strconv.FormatInt(mtime.Unix(), 10)}})
+}
+
+func (c *Client) action(ctx context.Context, method, path, action string, params url.Values) error {
+	res, err := c.do(ctx, method, path, action, params, nil)
+	if err != nil {
+		return err
+	}
+	io.Copy(ioutil.Discard, res.Body)
+	return res.Body.Close()
+}
+
+func (c *Client) list(ctx context.Context, path, action string) (*statResponse, error) {
+	res, err := c.do(ctx, "GET", path, action, url.Values{"format": {"xml"}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	stat := &statResponse{}
+	dec := xml.NewDecoder(res.Body)
+	dec.CharsetReader = charsetReader
+	if err := dec.Decode(stat); err != nil {
+		return nil, fmt.Errorf("Unable to decode %s response: %s", action, err)
+	}
+	return stat, nil
+}
+
+// do sends a request for action on path. Non-2xx responses are returned as an *edgegrid.Error.
+func (c *Client) do(ctx context.Context, method, path, action string, params url.Values, body io.Reader) (*http.Response, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "https"
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	u := url.URL{Scheme: scheme, Host: c.Host, Path: path}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	header := "version=1&action=" + action
+	if len(params) > 0 {
+		header += "&" + params.Encode()
+	}
+	req.Header.Set(ActionHeader, header)
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		defer res.Body.Close()
+		data, _ := ioutil.ReadAll(io.LimitReader(res.Body, 64*1024))
+		return nil, edgegrid.NewError(res.StatusCode, res.Header, bytes.TrimSpace(data))
+	}
+	return res, nil
+}
+
+// charsetReader decodes the ISO-8859-1 XML NetStorage responds with.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1":
+		data, err := ioutil.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return strings.NewReader(string(runes)), nil
+	case "utf-8", "":
+		return input, nil
+	}
+	return nil, fmt.Errorf("Unsupported charset %s", charset)
+}
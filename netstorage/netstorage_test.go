@@ -0,0 +1,137 @@
+package netstorage_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/netstorage"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/netstorage/netstoragetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignVerify(t *testing.T) {
+	timestamp := time.Unix(1280000000, 0)
+	data, sign := netstorage.Sign("key1", "abcdefghij", "/123456/file.txt", "version=1&action=download", timestamp, "382644692")
+	assert.Equal(t, "5, 0.0.0.0, 0.0.0.0, 1280000000, 382644692, key1", data)
+
+	req := httptest.NewRequest("GET", "/123456/file.txt", nil)
+	req.Header.Set(netstorage.ActionHeader, "version=1&action=download")
+	req.Header.Set(netstorage.AuthDataHeader, data)
+	req.Header.Set(netstorage.AuthSignHeader, sign)
+	assert.NoError(t, netstorage.VerifyRequest(req, "key1", "abcdefghij"))
+	assert.Error(t, netstorage.VerifyRequest(req, "key1", "wrong"))
+	assert.Error(t, netstorage.VerifyRequest(req, "key2", "abcdefghij"))
+
+	req.Header.Set(netstorage.ActionHeader, "version=1&action=delete")
+	assert.Error(t, netstorage.VerifyRequest(req, "key1", "abcdefghij"))
+}
+
+func TestTransportRequiresAction(t *testing.T) {
+	transport := &netstorage.Transport{KeyName: "key1", Key: "secret"}
+	_, err := transport.RoundTrip(httptest.NewRequest("GET", "https://example-nsu.akamaihd.net/123456/", nil))
+	assert.Error(t, err)
+}
+
+// slowReader yields its data one byte at a time, so uploads cannot know their length.
+type slowReader struct {
+	data string
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, io.EOF
+	}
+	p[0] = r.data[0]
+	r.data = r.data[1:]
+	return 1, nil
+}
+
+func TestFileOperations(t *testing.T) {
+	s := netstoragetest.NewServer("key1", "secret")
+	defer s.Close()
+	c := s.Client()
+	ctx := context.Background()
+
+	mtime := time.Unix(1700000000, 0)
+	assert.NoError(t, c.Upload(ctx, "/123456/site/index.html", &slowReader{"<html></html>"}, netstorage.MTime(mtime)))
+
+	stat, err := c.Stat(ctx, "/123456/site/index.html")
+	assert.NoError(t, err)
+	assert.Equal(t, "file", stat.Type)
+	assert.Equal(t, int64(13), stat.Size)
+	assert.Equal(t, mtime, stat.ModTime())
+
+	body, err := c.Download(ctx, "/123456/site/index.html")
+	assert.NoError(t, err)
+	data, _ := ioutil.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "<html></html>", string(data))
+
+	assert.NoError(t, c.Mkdir(ctx, "/123456/site/assets"))
+	assert.NoError(t, c.Symlink(ctx, "/123456/site/home.html", "/123456/site/index.html"))
+	assert.NoError(t, c.Rename(ctx, "/123456/site/index.html", "/123456/site/main.html"))
+
+	files, err := c.Dir(ctx, "/123456/site")
+	assert.NoError(t, err)
+	if assert.Len(t, files, 3) {
+		assert.True(t, files[0].IsDir())
+		assert.Equal(t, "assets", files[0].Name)
+		assert.Equal(t, "symlink", files[1].Type)
+		assert.Equal(t, "/123456/site/index.html", files[1].Target)
+		assert.Equal(t, "main.html", files[2].Name)
+	}
+
+	assert.NoError(t, c.SetMTime(ctx, "/123456/site/main.html", time.Unix(1600000000, 0)))
+	stat, _ = c.Stat(ctx, "/123456/site/main.html")
+	assert.Equal(t, int64(1600000000), stat.MTime)
+
+	assert.NoError(t, c.Delete(ctx, "/123456/site/main.html"))
+	assert.NoError(t, c.Delete(ctx, "/123456/site/home.html"))
+	assert.NoError(t, c.Rmdir(ctx, "/123456/site/assets"))
+
+	_, err = c.Stat(ctx, "/123456/site/main.html")
+	var apiErr *edgegrid.Error
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	}
+}
+
+func TestUploadChecksum(t *testing.T) {
+	s := netstoragetest.NewServer("key1", "secret")
+	defer s.Close()
+	c := s.Client()
+	ctx := context.Background()
+
+	sum := sha256.Sum256([]byte("content"))
+	assert.NoError(t, c.Upload(ctx, "/123456/a.txt", strings.NewReader("content"), netstorage.SHA256(hex.EncodeToString(sum[:]))))
+	data, ok := s.File("/123456/a.txt")
+	assert.True(t, ok)
+	assert.Equal(t, "content", string(data))
+
+	assert.Error(t, c.Upload(ctx, "/123456/b.txt", strings.NewReader("tampered"), netstorage.SHA256(hex.EncodeToString(sum[:]))))
+	_, ok = s.File("/123456/b.txt")
+	assert.False(t, ok)
+}
+
+func TestBadCredentials(t *testing.T) {
+	s := netstoragetest.NewServer("key1", "secret")
+	defer s.Close()
+	s.Put("/123456/a.txt", []byte("a"))
+
+	c := s.Client()
+	c.HTTPClient.Transport.(*netstorage.Transport).Key = "wrong"
+	_, err := c.Download(context.Background(), "/123456/a.txt")
+	if assert.IsType(t, &edgegrid.Error{}, err) {
+		assert.Equal(t, http.StatusForbidden, err.(*edgegrid.Error).StatusCode)
+	}
+}
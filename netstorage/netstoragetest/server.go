@@ -0,0 +1,310 @@
+// Package netstoragetest provides an in-process stand-in for the NetStorage HTTP API, for
+// testing code built on the netstorage package without network access.
+package netstoragetest
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/netstorage"
+)
+
+// entry is a file, directory or symlink of the in-memory storage.
+type entry struct {
+	dir    bool
+	data   []byte
+	target string
+	mtime  time.Time
+}
+
+// Server is an in-memory NetStorage stand-in verifying ACS signatures. Entries are keyed by
+// their full path, including the CP code.
+type Server struct {
+	// URL is the base URL of the server, e.g. https://127.0.0.1:12345.
+	URL string
+
+	// KeyName and Key are the upload account credentials the server accepts.
+	KeyName string
+	Key     string
+
+	server *httptest.Server
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+// NewServer starts a Server accepting keyName and key.
+func NewServer(keyName, key string) *Server {
+	s := &Server{
+		KeyName: keyName,
+		Key:     key,
+		entries: map[string]*entry{},
+	}
+	s.server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a netstorage.Client configured to talk to the server.
+func (s *Server) Client() *netstorage.Client {
+	httpClient := s.server.Client()
+	httpClient.Transport = &netstorage.Transport{KeyName: s.KeyName, Key: s.Key, Base: httpClient.Transport}
+	return &netstorage.Client{
+		HTTPClient: httpClient,
+		Host:       strings.TrimPrefix(s.URL, "https://"),
+	}
+}
+
+// Put stores a file at name, creating its parent directories.
+func (s *Server) Put(name string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mkdirAll(path.Dir(name))
+	s.entries[name] = &entry{data: data, mtime: time.Now()}
+}
+
+// File returns the contents of the file at name.
+func (s *Server) File(name string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[name]
+	if !ok || e.dir || e.target != "" {
+		return nil, false
+	}
+	return e.data, true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := netstorage.VerifyRequest(r, s.KeyName, s.Key); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	params, err := url.ParseQuery(r.Header.Get(netstorage.ActionHeader))
+	if err != nil || params.Get("version") != "1" {
+		http.Error(w, "Invalid action header", http.StatusBadRequest)
+		return
+	}
+
+	name := path.Clean(r.URL.Path)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch action := params.Get("action"); {
+	case action == "upload" && r.Method == "PUT":
+		s.upload(w, r, name, params)
+	case action == "download" && r.Method == "GET":
+		e, ok := s.entries[name]
+		if !ok || e.dir {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(e.data)
+	case (action == "stat" || action == "dir") && r.Method == "GET":
+		s.list(w, name, action)
+	case action == "mkdir" && r.Method == "PUT":
+		if _, ok := s.entries[name]; ok {
+			http.Error(w, "Conflict", http.StatusConflict)
+			return
+		}
+		s.mkdirAll(name)
+		writeMessage(w, "successful")
+	case action == "rmdir" && r.Method == "POST":
+		e, ok := s.entries[name]
+		if !ok || !e.dir {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		if len(s.children(name)) > 0 {
+			http.Error(w, "Directory not empty", http.StatusConflict)
+			return
+		}
+		delete(s.entries, name)
+		writeMessage(w, "successful")
+	case action == "delete" && r.Method == "POST":
+		e, ok := s.entries[name]
+		if !ok || e.dir {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		delete(s.entries, name)
+		writeMessage(w, "successful")
+	case action == "rename" && r.Method == "POST":
+		e, ok := s.entries[name]
+		destination := path.Clean(params.Get("destination"))
+		if !ok || e.dir || params.Get("destination") == "" {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		s.mkdirAll(path.Dir(destination))
+		s.entries[destination] = e
+		delete(s.entries, name)
+		writeMessage(w, "renamed")
+	case action == "symlink" && r.Method == "POST":
+		if params.Get("target") == "" {
+			http.Error(w, "Missing target", http.StatusBadRequest)
+			return
+		}
+		s.mkdirAll(path.Dir(name))
+		s.entries[name] = &entry{target: params.Get("target"), mtime: time.Now()}
+		writeMessage(w, "successful")
+	case action == "mtime" && r.Method == "POST":
+		e, ok := s.entries[name]
+		mtime, err := strconv.ParseInt(params.Get("mtime"), 10, 64)
+		if !ok || err != nil {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		e.mtime = time.Unix(mtime, 0)
+		writeMessage(w, "successful")
+	default:
+		http.Error(w, "Unsupported action", http.StatusBadRequest)
+	}
+}
+
+func (s *Server) upload(w http.ResponseWriter, r *http.Request, name string, params url.Values) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if e, ok := s.entries[name]; ok && e.dir {
+		http.Error(w, "Conflict", http.StatusConflict)
+		return
+	}
+	if sum := params.Get("sha256"); sum != "" {
+		actual := sha256.Sum256(data)
+		if hex.EncodeToString(actual[:]) != sum {
+			http.Error(w, "Checksum mismatch", http.StatusConflict)
+			return
+		}
+	}
+
+	mtime := time.Now()
+	if value := params.Get("mtime"); value != "" {
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid mtime", http.StatusBadRequest)
+			return
+		}
+		mtime = time.Unix(seconds, 0)
+	}
+
+	s.mkdirAll(path.Dir(name))
+	s.entries[name] = &entry{data: data, mtime: mtime}
+	writeMessage(w, "Request Processed.")
+}
+
+type statXML struct {
+	XMLName   xml.Name          `xml:"stat"`
+	Directory string            `xml:"directory,attr"`
+	Files     []netstorage.File `xml:"file"`
+}
+
+func (s *Server) list(w http.ResponseWriter, name, action string) {
+	e, ok := s.entries[name]
+	if !ok {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	stat := statXML{Directory: path.Dir(name)}
+	if action == "stat" {
+		stat.Files = []netstorage.File{fileOf(path.Base(name), e)}
+	} else {
+		if !e.dir {
+			http.Error(w, "Not a directory", http.StatusBadRequest)
+			return
+		}
+		stat.Directory = name
+		for _, child := range s.children(name) {
+			stat.Files = append(stat.Files, fileOf(path.Base(child), s.entries[child]))
+		}
+	}
+
+	data, err := xml.Marshal(stat)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/xml")
+	w.Write([]byte(`<?xml version="1.0" encoding="ISO-8859-1"?>` + "\n"))
+	w.Write(latin1(data))
+}
+
+// latin1 transcodes UTF-8 XML to the ISO-8859-1 NetStorage responds with, writing the characters
+// outside of it as character references.
+func latin1(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	for _, r := range string(data) {
+		if r < 0x100 {
+			out = append(out, byte(r))
+		} else {
+			out = append(out, fmt.Sprintf("&#%d;", r)...)
+		}
+	}
+	return out
+}
+
+func fileOf(name string, e *entry) netstorage.File {
+	f := netstorage.File{Name: name, MTime: e.mtime.Unix()}
+	switch {
+	case e.dir:
+		f.Type = "dir"
+	case e.target != "":
+		f.Type = "symlink"
+		f.Target = e.target
+	default:
+		f.Type = "file"
+		f.Size = int64(len(e.data))
+		sum := md5.Sum(e.data)
+		f.MD5 = hex.EncodeToString(sum[:])
+	}
+	return f
+}
+
+// children returns the sorted paths of the direct children of dir.
+func (s *Server) children(dir string) []string {
+	var children []string
+	for name := range s.entries {
+		if name != dir && path.Dir(name) == dir {
+			children = append(children, name)
+		}
+	}
+	sort.Strings(children)
+	return children
+}
+
+// mkdirAll creates dir and its parents, except the root.
+func (s *Server) mkdirAll(dir string) {
+	for ; dir != "/" && dir != "."; dir = path.Dir(dir) {
+		if _, ok := s.entries[dir]; !ok {
+			s.entries[dir] = &entry{dir: true, mtime: time.Now()}
+		}
+	}
+}
+
+func writeMessage(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "text/html")
+	fmt.Fprintf(w, "<HTML>%s</HTML>", message)
+}
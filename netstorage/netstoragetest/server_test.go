@@ -0,0 +1,74 @@
+package netstoragetest
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/netstorage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPutFile(t *testing.T) {
+	s := NewServer("key1", "secret")
+	defer s.Close()
+
+	s.Put("/123456/site/a.txt", []byte("a"))
+	data, ok := s.File("/123456/site/a.txt")
+	assert.True(t, ok)
+	assert.Equal(t, "a", string(data))
+
+	_, ok = s.File("/123456/site")
+	assert.False(t, ok)
+	_, ok = s.File("/123456/site/b.txt")
+	assert.False(t, ok)
+}
+
+func TestListLatin1(t *testing.T) {
+	s := NewServer("key1", "secret")
+	defer s.Close()
+	s.Put("/123456/café.txt", []byte("a"))
+	s.Put("/123456/日本.txt", []byte("b"))
+
+	req, _ := http.NewRequest("GET", s.URL+"/123456", nil)
+	req.Header.Set(netstorage.ActionHeader, "version=1&action=dir")
+	res, err := s.Client().HTTPClient.Do(req)
+	assert.NoError(t, err)
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, string(body), "caf\xe9.txt")
+	assert.Contains(t, string(body), "&#26085;&#26412;.txt")
+
+	files, err := s.Client().Dir(context.Background(), "/123456")
+	assert.NoError(t, err)
+	if assert.Len(t, files, 2) {
+		assert.Equal(t, "café.txt", files[0].Name)
+		assert.Equal(t, "日本.txt", files[1].Name)
+	}
+}
+
+func TestUnsigned(t *testing.T) {
+	s := NewServer("key1", "secret")
+	defer s.Close()
+	s.Put("/123456/a.txt", []byte("a"))
+
+	req, _ := http.NewRequest("GET", s.URL+"/123456/a.txt", nil)
+	req.Header.Set(netstorage.ActionHeader, "version=1&action=download")
+	res, err := s.server.Client().Do(req)
+	assert.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	res, err = s.Client().HTTPClient.Do(req)
+	assert.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	req.Header.Set(netstorage.ActionHeader, "version=2&action=download")
+	res, err = s.Client().HTTPClient.Do(req)
+	assert.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
@@ -0,0 +1,243 @@
+// Package networklists provides a client for the Akamai Network Lists API.
+//
+// Network lists are shared sets of IP addresses, CIDR blocks or geographic
+// locations used by security products. Every list carries a sync-point that
+// is incremented on each change; full updates send the sync-point they are
+// based on, so concurrent edits fail with ErrConflict instead of silently
+// overwriting each other. Modify wraps this in a read-modify-write loop.
+package networklists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// ListType is the kind of elements a network list holds.
+type ListType string
+
+// Network is the Akamai network a list is activated on.
+type Network string
+
+const (
+	// IP lists hold IP addresses and CIDR blocks.
+	IP ListType = "IP"
+	// GEO lists hold ISO 3166 country codes.
+	GEO ListType = "GEO"
+
+	// Staging is the Akamai staging network.
+	Staging Network = "staging"
+	// Production is the Akamai production network.
+	Production Network = "production"
+)
+
+// ErrConflict is returned when a list was changed by someone else since the sync-point it was read at.
+var ErrConflict = errors.New("Network list was modified concurrently, sync-point is out of date")
+
+// NetworkList is a network list.
+type NetworkList struct {
+	UniqueID    string   `json:"unique-id,omitempty"`
+	Name        string   `json:"name"`
+	Type        ListType `json:"type"`
+	Description string   `json:"description,omitempty"`
+	List        []string `json:"list"`
+	SyncPoint   int      `json:"sync-point"`
+	NumEntries  int      `json:"numEntries,omitempty"`
+	ReadOnly    bool     `json:"readOnly,omitempty"`
+	Shared      bool     `json:"shared,omitempty"`
+	Account     string   `json:"account,omitempty"`
+	CreateDate  int64    `json:"createDate,omitempty"`
+	CreatedBy   string   `json:"createdBy,omitempty"`
+	UpdateDate  int64    `json:"updateDate,omitempty"`
+	UpdatedBy   string   `json:"updatedBy,omitempty"`
+
+	ProductionActivationStatus string `json:"productionActivationStatus,omitempty"`
+	StagingActivationStatus    string `json:"stagingActivationStatus,omitempty"`
+}
+
+// ListsResponse is the response of the list endpoint.
+type ListsResponse struct {
+	NetworkLists []NetworkList `json:"network_lists"`
+}
+
+// UpdateResponse is the response to changes of a network list.
+type UpdateResponse struct {
+	Status    int    `json:"status"`
+	UniqueID  string `json:"unique-id"`
+	SyncPoint *int   `json:"sync-point,omitempty"`
+	Message   string `json:"message"`
+}
+
+// ActivationRequest is the body of an activation request.
+type ActivationRequest struct {
+	SiebelTicketID         string   `json:"siebel-ticket-id,omitempty"`
+	Comments               string   `json:"comments,omitempty"`
+	NotificationRecipients []string `json:"notification-recipients,omitempty"`
+}
+
+// ActivationStatus is the activation state of a list on a network.
+type ActivationStatus struct {
+	UniqueID           string `json:"uniqueId"`
+	ActivationStatus   string `json:"activationStatus"`
+	ActivationComments string `json:"activationComments,omitempty"`
+	SyncPoint          int    `json:"syncPoint"`
+}
+
+// Client is a Network Lists API client.
+type Client struct {
+	client *edgegrid.Client
+
+	// ConflictRetries is how many times Modify re-reads and re-applies changes after a conflict.
+	ConflictRetries int
+}
+
+// New creates a Network Lists client sending requests with client.
+func New(client *edgegrid.Client) *Client {
+	return &Client{client: client, ConflictRetries: 3}
+}
+
+// List lists the network lists of listType, or all lists if listType is empty.
+func (c *Client) List(ctx context.Context, listType ListType) ([]NetworkList, error) {
+	query := url.Values{}
+	query.Set("extended", "true")
+	if listType != "" {
+		query.Set("listType", string(listType))
+	}
+
+	res, _, err := edgegrid.GetJSON[ListsResponse](ctx, c.client, "/network-list/v1/network_lists", query)
+	return res.NetworkLists, err
+}
+
+// Get returns the network list uniqueID, including its elements.
+func (c *Client) Get(ctx context.Context, uniqueID string) (*NetworkList, error) {
+	query := url.Values{}
+	query.Set("extended", "true")
+
+	list, _, err := edgegrid.GetJSON[*NetworkList](ctx, c.client, listPath(uniqueID), query)
+	if err == nil && list == nil {
+		return nil, fmt.Errorf("Network list %s has an empty response", uniqueID)
+	}
+	return list, err
+}
+
+// Create creates a network list and returns its unique ID.
+func (c *Client) Create(ctx context.Context, list *NetworkList) (string, error) {
+	if list.List == nil {
+		list.List = []string{}
+	}
+
+	res, _, err := edgegrid.SendJSON[*NetworkList, UpdateResponse](ctx, c.client, "POST", "/network-list/v1/network_lists", list)
+	if err != nil {
+		return "", err
+	}
+	list.UniqueID = res.UniqueID
+	return res.UniqueID, nil
+}
+
+// Update replaces the network list with list. The request carries list.SyncPoint; if the list has
+// changed since, the update is rejected with ErrConflict. On success list.SyncPoint is advanced.
+func (c *Client) Update(ctx context.Context, list *NetworkList) (*UpdateResponse, error) {
+	if list.UniqueID == "" {
+		return nil, errors.New("Network list has no unique-id")
+	}
+
+	res, _, err := edgegrid.SendJSON[*NetworkList, UpdateResponse](ctx, c.client, "PUT", listPath(list.UniqueID), list)
+	if err != nil {
+		return nil, conflict(err)
+	}
+
+	if res.SyncPoint != nil {
+		list.SyncPoint = *res.SyncPoint
+	} else {
+		list.SyncPoint++
+	}
+	return &res, nil
+}
+
+// Modify applies fn to the current version of network list uniqueID and saves the result. If the
+// list is changed concurrently, the list is read again and fn re-applied, up to ConflictRetries
+// times, after which ErrConflict is returned. fn must therefore be safe to call more than once.
+func (c *Client) Modify(ctx context.Context, uniqueID string, fn func(list *NetworkList) error) (*NetworkList, error) {
+	for attempt := 0; ; attempt++ {
+		list, err := c.Get(ctx, uniqueID)
+		if err != nil {
+			return nil, err
+		}
+		if err := fn(list); err != nil {
+			return nil, err
+		}
+
+		_, err = c.Update(ctx, list)
+		if err == nil {
+			return list, nil
+		}
+		if !errors.Is(err, ErrConflict) || attempt >= c.ConflictRetries {
+			return nil, err
+		}
+	}
+}
+
+// Append adds elements to the network list uniqueID.
+func (c *Client) Append(ctx context.Context, uniqueID string, elements ...string) (*UpdateResponse, error) {
+	body := map[string][]string{"list": elements}
+	res, _, err := edgegrid.SendJSON[map[string][]string, UpdateResponse](ctx, c.client, "POST", listPath(uniqueID), body)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// AddElement adds a single element to the network list uniqueID.
+func (c *Client) AddElement(ctx context.Context, uniqueID, element string) (*UpdateResponse, error) {
+	return c.element(ctx, "PUT", uniqueID, element)
+}
+
+// RemoveElement removes a single element from the network list uniqueID.
+func (c *Client) RemoveElement(ctx context.Context, uniqueID, element string) (*UpdateResponse, error) {
+	return c.element(ctx, "DELETE", uniqueID, element)
+}
+
+// Activate activates the network list uniqueID on network.
+func (c *Client) Activate(ctx context.Context, uniqueID string, network Network, req ActivationRequest) (*UpdateResponse, error) {
+	path := fmt.Sprintf("%s/activate?env=%s", listPath(uniqueID), network)
+	res, _, err := edgegrid.SendJSON[ActivationRequest, UpdateResponse](ctx, c.client, "POST", path, req)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// ActivationStatus returns the activation status of the network list uniqueID on network.
+func (c *Client) ActivationStatus(ctx context.Context, uniqueID string, network Network) (*ActivationStatus, error) {
+	query := url.Values{}
+	query.Set("env", string(network))
+
+	status, _, err := edgegrid.GetJSON[*ActivationStatus](ctx, c.client, listPath(uniqueID)+"/status", query)
+	return status, err
+}
+
+func (c *Client) element(ctx context.Context, method, uniqueID, element string) (*UpdateResponse, error) {
+	path := fmt.Sprintf("%s/element?element=%s", listPath(uniqueID), url.QueryEscape(element))
+	res, _, err := edgegrid.SendJSON[*struct{}, UpdateResponse](ctx, c.client, method, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func listPath(uniqueID string) string {
+	return "/network-list/v1/network_lists/" + url.PathEscape(uniqueID)
+}
+
+// conflict translates 409 Conflict responses into ErrConflict, keeping the API error.
+func conflict(err error) error {
+	var apiErr *edgegrid.Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+		return fmt.Errorf("%w: %w", ErrConflict, apiErr)
+	}
+	return err
+}
@@ -0,0 +1,226 @@
+package networklists
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/edgegridtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAPI keeps a single network list and enforces sync-points like the real API.
+type fakeAPI struct {
+	mu   sync.Mutex
+	list NetworkList
+
+	// beforeUpdate runs before each PUT is applied, e.g. to simulate a concurrent edit.
+	beforeUpdate func()
+}
+
+func newFakeServer(t *testing.T) (*edgegridtest.Server, *fakeAPI) {
+	api := &fakeAPI{list: NetworkList{
+		UniqueID:  "345_BOTLIST",
+		Name:      "Simple List",
+		Type:      IP,
+		List:      []string{"192.168.0.1"},
+		SyncPoint: 0,
+	}}
+
+	s := edgegridtest.NewServer()
+	s.HandleFunc("GET /network-list/v1/network_lists", func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		assert.Equal(t, "IP", r.URL.Query().Get("listType"))
+		json.NewEncoder(w).Encode(ListsResponse{NetworkLists: []NetworkList{api.list}})
+	})
+	s.HandleFunc("POST /network-list/v1/network_lists", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"status":201,"unique-id":"346_NEWLIST","message":"created"}`))
+	})
+	s.HandleFunc("GET /network-list/v1/network_lists/345_BOTLIST", func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		json.NewEncoder(w).Encode(api.list)
+	})
+	s.HandleFunc("PUT /network-list/v1/network_lists/345_BOTLIST", func(w http.ResponseWriter, r *http.Request) {
+		if api.beforeUpdate != nil {
+			api.beforeUpdate()
+		}
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		update := NetworkList{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+		if update.SyncPoint != api.list.SyncPoint {
+			edgegridtest.WriteProblem(w, r, http.StatusConflict, "Conflict", "sync-point mismatch")
+			return
+		}
+		update.SyncPoint++
+		api.list = update
+		json.NewEncoder(w).Encode(UpdateResponse{Status: 200, UniqueID: update.UniqueID, SyncPoint: &update.SyncPoint})
+	})
+	s.HandleFunc("POST /network-list/v1/network_lists/345_BOTLIST", func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		var body struct {
+			List []string `json:"list"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		api.list.List = append(api.list.List, body.List...)
+		api.list.SyncPoint++
+		w.Write([]byte(`{"status":200,"unique-id":"345_BOTLIST","message":"appended"}`))
+	})
+	s.HandleFunc("/network-list/v1/network_lists/345_BOTLIST/element", func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		element := r.URL.Query().Get("element")
+		switch r.Method {
+		case "PUT":
+			api.list.List = append(api.list.List, element)
+		case "DELETE":
+			var kept []string
+			for _, e := range api.list.List {
+				if e != element {
+					kept = append(kept, e)
+				}
+			}
+			api.list.List = kept
+		}
+		api.list.SyncPoint++
+		w.Write([]byte(`{"status":200,"unique-id":"345_BOTLIST"}`))
+	})
+	s.HandleFunc("POST /network-list/v1/network_lists/345_BOTLIST/activate", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "production", r.URL.Query().Get("env"))
+		w.Write([]byte(`{"status":200,"unique-id":"345_BOTLIST","message":"activation pending"}`))
+	})
+	s.HandleFunc("GET /network-list/v1/network_lists/345_BOTLIST/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"uniqueId":"345_BOTLIST","activationStatus":"ACTIVE","syncPoint":3}`))
+	})
+
+	return s, api
+}
+
+func TestListGetCreate(t *testing.T) {
+	s, _ := newFakeServer(t)
+	defer s.Close()
+	c := New(s.Client())
+	ctx := context.Background()
+
+	lists, err := c.List(ctx, IP)
+	assert.NoError(t, err)
+	assert.Len(t, lists, 1)
+
+	list, err := c.Get(ctx, "345_BOTLIST")
+	assert.NoError(t, err)
+	assert.Equal(t, "Simple List", list.Name)
+	assert.Equal(t, []string{"192.168.0.1"}, list.List)
+
+	created := &NetworkList{Name: "New", Type: GEO}
+	id, err := c.Create(ctx, created)
+	assert.NoError(t, err)
+	assert.Equal(t, "346_NEWLIST", id)
+	assert.Equal(t, "346_NEWLIST", created.UniqueID)
+	assert.JSONEq(t, `{"name":"New","type":"GEO","list":[],"sync-point":0}`, string(s.Requests()[2].Body))
+}
+
+func TestUpdateConflict(t *testing.T) {
+	s, _ := newFakeServer(t)
+	defer s.Close()
+	c := New(s.Client())
+	ctx := context.Background()
+
+	first, _ := c.Get(ctx, "345_BOTLIST")
+	second, _ := c.Get(ctx, "345_BOTLIST")
+
+	first.List = append(first.List, "10.0.0.1")
+	_, err := c.Update(ctx, first)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, first.SyncPoint)
+
+	second.List = []string{"10.0.0.2"}
+	_, err = c.Update(ctx, second)
+	assert.True(t, errors.Is(err, ErrConflict))
+	var apiErr *edgegrid.Error
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusConflict, apiErr.StatusCode)
+	}
+
+	current, _ := c.Get(ctx, "345_BOTLIST")
+	assert.Equal(t, []string{"192.168.0.1", "10.0.0.1"}, current.List)
+}
+
+func TestModifyRetriesConflicts(t *testing.T) {
+	s, api := newFakeServer(t)
+	defer s.Close()
+	c := New(s.Client())
+	ctx := context.Background()
+
+	concurrent := 1
+	api.beforeUpdate = func() {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+		if concurrent > 0 {
+			concurrent--
+			api.list.List = append(api.list.List, "172.16.0.1")
+			api.list.SyncPoint++
+		}
+	}
+
+	calls := 0
+	list, err := c.Modify(ctx, "345_BOTLIST", func(list *NetworkList) error {
+		calls++
+		list.List = append(list.List, "10.0.0.1")
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []string{"192.168.0.1", "172.16.0.1", "10.0.0.1"}, list.List)
+
+	c.ConflictRetries = 0
+	concurrent = 1
+	_, err = c.Modify(ctx, "345_BOTLIST", func(list *NetworkList) error { return nil })
+	assert.True(t, errors.Is(err, ErrConflict))
+}
+
+func TestModifyEmptyResponse(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleFunc("GET /network-list/v1/network_lists/345_BOTLIST", func(w http.ResponseWriter, r *http.Request) {})
+
+	called := false
+	_, err := New(s.Client()).Modify(context.Background(), "345_BOTLIST", func(list *NetworkList) error {
+		called = true
+		return nil
+	})
+	assert.EqualError(t, err, "Network list 345_BOTLIST has an empty response")
+	assert.False(t, called)
+	assert.Len(t, s.Requests(), 1)
+}
+
+func TestElementsAndActivation(t *testing.T) {
+	s, api := newFakeServer(t)
+	defer s.Close()
+	c := New(s.Client())
+	ctx := context.Background()
+
+	_, err := c.Append(ctx, "345_BOTLIST", "10.0.0.1", "10.0.0.2")
+	assert.NoError(t, err)
+	_, err = c.AddElement(ctx, "345_BOTLIST", "10.0.0.0/24")
+	assert.NoError(t, err)
+	_, err = c.RemoveElement(ctx, "345_BOTLIST", "192.168.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2", "10.0.0.0/24"}, api.list.List)
+
+	res, err := c.Activate(ctx, "345_BOTLIST", Production, ActivationRequest{Comments: "go"})
+	assert.NoError(t, err)
+	assert.Equal(t, "activation pending", res.Message)
+
+	status, err := c.ActivationStatus(ctx, "345_BOTLIST", Production)
+	assert.NoError(t, err)
+	assert.Equal(t, "ACTIVE", status.ActivationStatus)
+}
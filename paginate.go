@@ -0,0 +1,266 @@
+package edgegrid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// PageStrategy decides how a Paginator requests successive pages of a list endpoint.
+type PageStrategy interface {
+	// First sets up the query of the first page request.
+	First(query url.Values)
+
+	// Next returns the URL of the page following the one requested with current, whose
+	// body contained count items. It returns an empty string after the last page.
+	Next(current *url.URL, body []byte, count int) (string, error)
+}
+
+// OffsetLimit pages with offset and limit query parameters, e.g. ?offset=0&limit=100.
+// Paging stops at the first page with fewer than Limit items.
+type OffsetLimit struct {
+	OffsetParam string
+	LimitParam  string
+	Limit       int
+}
+
+// NewOffsetLimit returns an OffsetLimit strategy using the "offset" and "limit" parameters.
+func NewOffsetLimit(limit int) *OffsetLimit {
+	return &OffsetLimit{OffsetParam: "offset", LimitParam: "limit", Limit: limit}
+}
+
+// First implements PageStrategy.
+func (o *OffsetLimit) First(query url.Values) {
+	query.Set(o.OffsetParam, "0")
+	query.Set(o.LimitParam, strconv.Itoa(o.Limit))
+}
+
+// Next implements PageStrategy.
+func (o *OffsetLimit) Next(current *url.URL, body []byte, count int) (string, error) {
+	if count == 0 || count < o.Limit {
+		return "", nil
+	}
+
+	query := current.Query()
+	offset, _ := strconv.Atoi(query.Get(o.OffsetParam))
+	query.Set(o.OffsetParam, strconv.Itoa(offset+count))
+
+	next := *current
+	next.RawQuery = query.Encode()
+	return next.String(), nil
+}
+
+// PageNumber pages with page number and page size query parameters, e.g. ?page=1&pageSize=100.
+// Paging stops at the first page with fewer than Size items.
+type PageNumber struct {
+	PageParam string
+	SizeParam string
+	Size      int
+
+	// FirstPage is the number of the first page, usually 0 or 1.
+	FirstPage int
+}
+
+// NewPageNumber returns a PageNumber strategy using the "page" and "pageSize" parameters, starting at page 1.
+func NewPageNumber(size int) *PageNumber {
+	return &PageNumber{PageParam: "page", SizeParam: "pageSize", Size: size, FirstPage: 1}
+}
+
+// First implements PageStrategy.
+func (p *PageNumber) First(query url.Values) {
+	query.Set(p.PageParam, strconv.Itoa(p.FirstPage))
+	query.Set(p.SizeParam, strconv.Itoa(p.Size))
+}
+
+// Next implements PageStrategy.
+func (p *PageNumber) Next(current *url.URL, body []byte, count int) (string, error) {
+	if count == 0 || count < p.Size {
+		return "", nil
+	}
+
+	query := current.Query()
+	page, err := strconv.Atoi(query.Get(p.PageParam))
+	if err != nil {
+		page = p.FirstPage
+	}
+	query.Set(p.PageParam, strconv.Itoa(page+1))
+
+	next := *current
+	next.RawQuery = query.Encode()
+	return next.String(), nil
+}
+
+// HALNext follows the _links.next.href link of HAL responses until it is absent.
+type HALNext struct{}
+
+// First implements PageStrategy.
+func (HALNext) First(query url.Values) {}
+
+// Next implements PageStrategy.
+func (HALNext) Next(current *url.URL, body []byte, count int) (string, error) {
+	var links struct {
+		Links struct {
+			Next struct {
+				Href string `json:"href"`
+			} `json:"next"`
+		} `json:"_links"`
+	}
+	if err := json.Unmarshal(body, &links); err != nil {
+		return "", err
+	}
+	if links.Links.Next.Href == "" {
+		return "", nil
+	}
+
+	href, err := url.Parse(links.Links.Next.Href)
+	if err != nil {
+		return "", err
+	}
+	return current.ResolveReference(href).String(), nil
+}
+
+// ItemsAt returns an item extractor for Paginator that decodes the array found by following
+// keys through nested JSON objects, e.g. ItemsAt[Group]("groups", "items"). With no keys the
+// body itself must be an array.
+func ItemsAt[T any](keys ...string) func(body []byte) ([]T, error) {
+	return func(body []byte) ([]T, error) {
+		raw := json.RawMessage(body)
+		for _, key := range keys {
+			var object map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &object); err != nil {
+				return nil, err
+			}
+			value, ok := object[key]
+			if !ok {
+				return nil, nil
+			}
+			raw = value
+		}
+
+		var items []T
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+}
+
+// Paginator iterates over all items of a paged list endpoint.
+type Paginator[T any] struct {
+	client   *Client
+	path     string
+	strategy PageStrategy
+	items    func(body []byte) ([]T, error)
+
+	// MaxPages stops iteration after this many pages when greater than zero.
+	MaxPages int
+}
+
+// NewPaginator creates a Paginator for the endpoint at path, a URL relative to the Client's BaseURL.
+// strategy decides how successive pages are requested and items extracts the items from each page body,
+// see ItemsAt.
+func NewPaginator[T any](client *Client, path string, strategy PageStrategy, items func(body []byte) ([]T, error)) *Paginator[T] {
+	return &Paginator[T]{
+		client:   client,
+		path:     path,
+		strategy: strategy,
+		items:    items,
+	}
+}
+
+// Pages returns an iterator over the pages of items. Iteration stops after the first error,
+// which is yielded with a nil page, or when ctx is done.
+func (p *Paginator[T]) Pages(ctx context.Context) iter.Seq2[[]T, error] {
+	return func(yield func([]T, error) bool) {
+		rel, err := url.Parse(p.path)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		query := rel.Query()
+		p.strategy.First(query)
+		rel.RawQuery = query.Encode()
+		next := rel.String()
+
+		for page := 1; next != ""; page++ {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			current, body, err := p.fetch(ctx, next)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			items, err := p.items(body)
+			if err != nil {
+				yield(nil, fmt.Errorf("Unable to decode page %d: %s", page, err))
+				return
+			}
+
+			next, err = p.strategy.Next(current, body, len(items))
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if p.MaxPages > 0 && page >= p.MaxPages {
+				next = ""
+			}
+
+			if !yield(items, nil) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over every item of every page. Iteration stops after the first
+// error, which is yielded with the zero value of T.
+func (p *Paginator[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for items, err := range p.Pages(ctx) {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Collect fetches every page and returns all items. On error the items collected so far are returned.
+func (p *Paginator[T]) Collect(ctx context.Context) ([]T, error) {
+	var all []T
+	for items, err := range p.Pages(ctx) {
+		if err != nil {
+			return all, err
+		}
+		all = append(all, items...)
+	}
+	return all, nil
+}
+
+func (p *Paginator[T]) fetch(ctx context.Context, urlStr string) (*url.URL, []byte, error) {
+	req, err := p.client.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	_, body, err := p.client.Send(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return req.URL, body, nil
+}
@@ -0,0 +1,136 @@
+package edgegrid_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/edgegridtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	ID int `json:"id"`
+}
+
+// serveItems serves 7 items, selecting the window with from and to.
+func serveItems(w http.ResponseWriter, from, to int, extra map[string]interface{}) {
+	items := []item{}
+	for i := from; i < to && i < 7; i++ {
+		items = append(items, item{ID: i})
+	}
+	body := map[string]interface{}{"items": items}
+	for k, v := range extra {
+		body[k] = v
+	}
+	json.NewEncoder(w).Encode(body)
+}
+
+func ids(items []item) []int {
+	var out []int
+	for _, i := range items {
+		out = append(out, i.ID)
+	}
+	return out
+}
+
+func TestPaginatorOffsetLimit(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleFunc("GET /items", func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		assert.Equal(t, "x", r.URL.Query().Get("filter"))
+		serveItems(w, offset, offset+limit, nil)
+	})
+
+	p := edgegrid.NewPaginator(s.Client(), "/items?filter=x", edgegrid.NewOffsetLimit(3), edgegrid.ItemsAt[item]("items"))
+	items, err := p.Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6}, ids(items))
+	assert.Len(t, s.Requests(), 3)
+}
+
+func TestPaginatorPageNumber(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleFunc("GET /items", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		serveItems(w, (page-1)*size, page*size, nil)
+	})
+
+	p := edgegrid.NewPaginator(s.Client(), "/items", edgegrid.NewPageNumber(7), edgegrid.ItemsAt[item]("items"))
+	items, err := p.Collect(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, items, 7)
+	assert.Len(t, s.Requests(), 2)
+}
+
+func TestPaginatorHALNext(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleFunc("GET /items", func(w http.ResponseWriter, r *http.Request) {
+		from, _ := strconv.Atoi(r.URL.Query().Get("from"))
+		links := map[string]interface{}{}
+		if from+4 < 7 {
+			links["next"] = map[string]string{"href": fmt.Sprintf("/items?from=%d", from+4)}
+		}
+		serveItems(w, from, from+4, map[string]interface{}{"_links": links})
+	})
+
+	p := edgegrid.NewPaginator(s.Client(), "/items", edgegrid.HALNext{}, edgegrid.ItemsAt[item]("items"))
+
+	var got []int
+	for i, err := range p.All(context.Background()) {
+		assert.NoError(t, err)
+		got = append(got, i.ID)
+		if i.ID == 5 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, got)
+}
+
+func TestPaginatorError(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleFunc("GET /items", func(w http.ResponseWriter, r *http.Request) {
+		serveItems(w, 0, 3, nil)
+	})
+	s.Script("GET /items", edgegridtest.Pass(), edgegridtest.ServerError(http.StatusInternalServerError))
+
+	p := edgegrid.NewPaginator(s.Client(), "/items", edgegrid.NewOffsetLimit(3), edgegrid.ItemsAt[item]("items"))
+	items, err := p.Collect(context.Background())
+	assert.Len(t, items, 3)
+	if assert.IsType(t, &edgegrid.Error{}, err) {
+		assert.Equal(t, http.StatusInternalServerError, err.(*edgegrid.Error).StatusCode)
+	}
+}
+
+func TestPaginatorCancel(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleFunc("GET /items", func(w http.ResponseWriter, r *http.Request) {
+		serveItems(w, 0, 3, nil)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := edgegrid.NewPaginator(s.Client(), "/items", edgegrid.NewOffsetLimit(3), edgegrid.ItemsAt[item]("items"))
+
+	pages := 0
+	for _, err := range p.Pages(ctx) {
+		if err != nil {
+			assert.Equal(t, context.Canceled, err)
+			break
+		}
+		pages++
+		cancel()
+	}
+	assert.Equal(t, 1, pages)
+}
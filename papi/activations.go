@@ -0,0 +1,75 @@
+package papi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Activation is the activation of a property version on a network.
+type Activation struct {
+	ActivationID           string   `json:"activationId,omitempty"`
+	PropertyName           string   `json:"propertyName,omitempty"`
+	PropertyID             string   `json:"propertyId,omitempty"`
+	PropertyVersion        int      `json:"propertyVersion"`
+	Network                Network  `json:"network"`
+	ActivationType         string   `json:"activationType,omitempty"`
+	Status                 string   `json:"status,omitempty"`
+	SubmitDate             string   `json:"submitDate,omitempty"`
+	UpdateDate             string   `json:"updateDate,omitempty"`
+	Note                   string   `json:"note,omitempty"`
+	NotifyEmails           []string `json:"notifyEmails"`
+	AcknowledgeAllWarnings bool     `json:"acknowledgeAllWarnings,omitempty"`
+}
+
+// Terminal reports whether the activation has reached a final status.
+func (a *Activation) Terminal() bool {
+	switch a.Status {
+	case "ACTIVE", "INACTIVE", "ABORTED", "FAILED", "DEACTIVATED":
+		return true
+	}
+	return false
+}
+
+type activationsResponse struct {
+	Activations struct {
+		Items []Activation `json:"items"`
+	} `json:"activations"`
+}
+
+// Activations lists the activations of the property propertyID.
+func (c *Client) Activations(ctx context.Context, contractID, groupID, propertyID string) ([]Activation, error) {
+	res, err := get[activationsResponse](ctx, c, propertyPath(propertyID)+"/activations", scope(contractID, groupID))
+	return res.Activations.Items, err
+}
+
+// Activation returns the activation activationID of the property propertyID.
+func (c *Client) Activation(ctx context.Context, contractID, groupID, propertyID, activationID string) (*Activation, error) {
+	res, err := get[activationsResponse](ctx, c, propertyPath(propertyID)+"/activations/"+url.PathEscape(activationID), scope(contractID, groupID))
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Activations.Items) == 0 {
+		return nil, fmt.Errorf("Activation %s not found", activationID)
+	}
+	return &res.Activations.Items[0], nil
+}
+
+// Activate requests the activation of a property version and returns the activation ID.
+// Set ActivationType to "DEACTIVATE" to deactivate instead.
+func (c *Client) Activate(ctx context.Context, contractID, groupID, propertyID string, activation Activation) (string, error) {
+	if activation.ActivationType == "" {
+		activation.ActivationType = "ACTIVATE"
+	}
+	if activation.NotifyEmails == nil {
+		activation.NotifyEmails = []string{}
+	}
+
+	res, err := send[struct {
+		ActivationLink string `json:"activationLink"`
+	}](ctx, c, "POST", propertyPath(propertyID)+"/activations", scope(contractID, groupID), activation)
+	if err != nil {
+		return "", err
+	}
+	return linkID(res.ActivationLink), nil
+}
@@ -0,0 +1,165 @@
+// Package papi provides a client for the Akamai Property Manager API (PAPI).
+//
+// It covers contracts, groups, products, properties, property versions,
+// hostnames, rule trees and activations. Rule tree types keep the fields
+// they do not model, see Rule.Extra, and exact numeric option values.
+package papi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// Network is the Akamai network a property version is activated on.
+type Network string
+
+const (
+	// Staging is the Akamai staging network.
+	Staging Network = "STAGING"
+	// Production is the Akamai production network.
+	Production Network = "PRODUCTION"
+)
+
+// Contract is a contract of the account.
+type Contract struct {
+	ContractID       string `json:"contractId"`
+	ContractTypeName string `json:"contractTypeName"`
+}
+
+// Group is a group of the account.
+type Group struct {
+	GroupID       string   `json:"groupId"`
+	GroupName     string   `json:"groupName"`
+	ParentGroupID string   `json:"parentGroupId,omitempty"`
+	ContractIDs   []string `json:"contractIds"`
+}
+
+// Product is a product available on a contract.
+type Product struct {
+	ProductID   string `json:"productId"`
+	ProductName string `json:"productName"`
+}
+
+type contractsResponse struct {
+	Contracts struct {
+		Items []Contract `json:"items"`
+	} `json:"contracts"`
+}
+
+type groupsResponse struct {
+	Groups struct {
+		Items []Group `json:"items"`
+	} `json:"groups"`
+}
+
+type productsResponse struct {
+	Products struct {
+		Items []Product `json:"items"`
+	} `json:"products"`
+}
+
+// Client is a Property Manager API client.
+type Client struct {
+	client *edgegrid.Client
+
+	// UsePrefixes sets the PAPI-Use-Prefixes header, which selects whether IDs are returned
+	// with their type prefix (e.g. "prp_12345") or as bare numbers. It defaults to true.
+	UsePrefixes bool
+}
+
+// New creates a Property Manager client sending requests with client.
+func New(client *edgegrid.Client) *Client {
+	return &Client{client: client, UsePrefixes: true}
+}
+
+// Contracts lists the contracts of the account.
+func (c *Client) Contracts(ctx context.Context) ([]Contract, error) {
+	res, err := get[contractsResponse](ctx, c, "/papi/v1/contracts", nil)
+	return res.Contracts.Items, err
+}
+
+// Groups lists the groups of the account.
+func (c *Client) Groups(ctx context.Context) ([]Group, error) {
+	res, err := get[groupsResponse](ctx, c, "/papi/v1/groups", nil)
+	return res.Groups.Items, err
+}
+
+// Products lists the products available on contractID.
+func (c *Client) Products(ctx context.Context, contractID string) ([]Product, error) {
+	query := url.Values{}
+	query.Set("contractId", contractID)
+
+	res, err := get[productsResponse](ctx, c, "/papi/v1/products", query)
+	return res.Products.Items, err
+}
+
+func (c *Client) newRequest(method, urlStr string, query url.Values, body interface{}) (*http.Request, error) {
+	var (
+		req *http.Request
+		err error
+	)
+	urlStr, err = edgegrid.WithQuery(urlStr, query)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		req, err = c.client.NewRequest(method, urlStr, nil)
+	} else {
+		req, err = c.client.NewJSONRequest(method, urlStr, body)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("PAPI-Use-Prefixes", strconv.FormatBool(c.UsePrefixes))
+	return req, nil
+}
+
+func get[T any](ctx context.Context, c *Client, urlStr string, query url.Values) (T, error) {
+	var result T
+	req, err := c.newRequest("GET", urlStr, query, nil)
+	if err != nil {
+		return result, err
+	}
+
+	result, _, err = edgegrid.DoJSON[T](ctx, c.client, req)
+	return result, err
+}
+
+func send[T any](ctx context.Context, c *Client, method, urlStr string, query url.Values, body interface{}) (T, error) {
+	var result T
+	req, err := c.newRequest(method, urlStr, query, body)
+	if err != nil {
+		return result, err
+	}
+
+	result, _, err = edgegrid.DoJSON[T](ctx, c.client, req)
+	return result, err
+}
+
+// scope returns the contractId and groupId query parameters most PAPI endpoints require.
+func scope(contractID, groupID string) url.Values {
+	query := url.Values{}
+	if contractID != "" {
+		query.Set("contractId", contractID)
+	}
+	if groupID != "" {
+		query.Set("groupId", groupID)
+	}
+	return query
+}
+
+// linkID returns the last path segment of a PAPI link, e.g. "prp_12345" from
+// "/papi/v1/properties/prp_12345?contractId=ctr_1&groupId=grp_2".
+func linkID(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return path.Base(u.Path)
+}
@@ -0,0 +1,120 @@
+package papi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/edgegridtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContractsGroupsProducts(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleRaw("GET /papi/v1/contracts", http.StatusOK, "application/json", []byte(`{"contracts":{"items":[{"contractId":"ctr_1-1TJZFW","contractTypeName":"DIRECT"}]}}`))
+	s.HandleRaw("GET /papi/v1/groups", http.StatusOK, "application/json", []byte(`{"groups":{"items":[{"groupId":"grp_15225","groupName":"Example","contractIds":["ctr_1-1TJZFW"]}]}}`))
+	s.HandleRaw("GET /papi/v1/products", http.StatusOK, "application/json", []byte(`{"products":{"items":[{"productId":"prd_Fresca","productName":"Ion Standard"}]}}`))
+
+	c := New(s.Client())
+	ctx := context.Background()
+
+	contracts, err := c.Contracts(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []Contract{{ContractID: "ctr_1-1TJZFW", ContractTypeName: "DIRECT"}}, contracts)
+
+	groups, err := c.Groups(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "grp_15225", groups[0].GroupID)
+
+	products, err := c.Products(ctx, "ctr_1-1TJZFW")
+	assert.NoError(t, err)
+	assert.Equal(t, "prd_Fresca", products[0].ProductID)
+
+	requests := s.Requests()
+	assert.Equal(t, "ctr_1-1TJZFW", requests[2].Query.Get("contractId"))
+	for _, req := range requests {
+		assert.Equal(t, "true", req.Header.Get("PAPI-Use-Prefixes"))
+	}
+
+	c.UsePrefixes = false
+	c.Contracts(ctx)
+	assert.Equal(t, "false", s.Requests()[3].Header.Get("PAPI-Use-Prefixes"))
+}
+
+func TestPropertiesAndVersions(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleRaw("GET /papi/v1/properties/prp_175780", http.StatusOK, "application/json", []byte(`{"properties":{"items":[{"propertyId":"prp_175780","propertyName":"example.com","latestVersion":2,"productionVersion":1}]}}`))
+	s.HandleFunc("POST /papi/v1/properties", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"propertyLink":"/papi/v1/properties/prp_173136?contractId=ctr_1&groupId=grp_2"}`))
+	})
+	s.HandleRaw("GET /papi/v1/properties/prp_175780/versions/latest", http.StatusOK, "application/json", []byte(`{"versions":{"items":[{"propertyVersion":1,"etag":"a9dfe78cf93090516bde891d009eaf57","productionStatus":"ACTIVE"}]}}`))
+	s.HandleFunc("POST /papi/v1/properties/prp_175780/versions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"versionLink":"/papi/v1/properties/prp_175780/versions/3?contractId=ctr_1&groupId=grp_2"}`))
+	})
+	s.HandleFunc("PUT /papi/v1/properties/prp_175780/versions/3/hostnames", func(w http.ResponseWriter, r *http.Request) {
+		hostnames := []Hostname{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&hostnames))
+		json.NewEncoder(w).Encode(hostnamesResponse{Hostnames: struct {
+			Items []Hostname `json:"items"`
+		}{hostnames}})
+	})
+
+	c := New(s.Client())
+	ctx := context.Background()
+
+	property, err := c.Property(ctx, "ctr_1", "grp_2", "prp_175780")
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", property.PropertyName)
+
+	id, err := c.CreateProperty(ctx, "ctr_1", "grp_2", CreatePropertyRequest{PropertyName: "new.example.com", ProductID: "prd_Fresca"})
+	assert.NoError(t, err)
+	assert.Equal(t, "prp_173136", id)
+
+	latest, err := c.LatestVersion(ctx, "ctr_1", "grp_2", "prp_175780", Production)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, latest.PropertyVersion)
+	assert.Equal(t, "PRODUCTION", s.Requests()[2].Query.Get("activatedOn"))
+
+	version, err := c.CreateVersion(ctx, "ctr_1", "grp_2", "prp_175780", latest.PropertyVersion, latest.Etag)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, version)
+	assert.JSONEq(t, `{"createFromVersion":1,"createFromVersionEtag":"a9dfe78cf93090516bde891d009eaf57"}`, string(s.Requests()[3].Body))
+
+	hostnames, err := c.UpdateHostnames(ctx, "ctr_1", "grp_2", "prp_175780", version, []Hostname{{CnameFrom: "www.example.com", CnameTo: "www.example.com.edgesuite.net", CnameType: "EDGE_HOSTNAME"}})
+	assert.NoError(t, err)
+	assert.Len(t, hostnames, 1)
+
+	_, err = c.Property(ctx, "ctr_1", "grp_2", "prp_missing")
+	if assert.IsType(t, &edgegrid.Error{}, err) {
+		assert.Equal(t, http.StatusNotFound, err.(*edgegrid.Error).StatusCode)
+	}
+}
+
+func TestActivations(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleFunc("POST /papi/v1/properties/prp_175780/activations", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"activationLink":"/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1&groupId=grp_2"}`))
+	})
+	s.HandleRaw("GET /papi/v1/properties/prp_175780/activations/atv_67037", http.StatusOK, "application/json", []byte(`{"activations":{"items":[{"activationId":"atv_67037","propertyVersion":3,"network":"STAGING","activationType":"ACTIVATE","status":"PENDING"}]}}`))
+
+	c := New(s.Client())
+	ctx := context.Background()
+
+	id, err := c.Activate(ctx, "ctr_1", "grp_2", "prp_175780", Activation{PropertyVersion: 3, Network: Staging, AcknowledgeAllWarnings: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "atv_67037", id)
+	assert.JSONEq(t, `{"propertyVersion":3,"network":"STAGING","activationType":"ACTIVATE","notifyEmails":[],"acknowledgeAllWarnings":true}`, string(s.Requests()[0].Body))
+
+	activation, err := c.Activation(ctx, "ctr_1", "grp_2", "prp_175780", id)
+	assert.NoError(t, err)
+	assert.Equal(t, "PENDING", activation.Status)
+	assert.False(t, activation.Terminal())
+}
@@ -0,0 +1,190 @@
+package papi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Property is a property configuration.
+type Property struct {
+	AccountID         string `json:"accountId"`
+	ContractID        string `json:"contractId"`
+	GroupID           string `json:"groupId"`
+	PropertyID        string `json:"propertyId"`
+	PropertyName      string `json:"propertyName"`
+	AssetID           string `json:"assetId,omitempty"`
+	ProductID         string `json:"productId,omitempty"`
+	RuleFormat        string `json:"ruleFormat,omitempty"`
+	Note              string `json:"note,omitempty"`
+	LatestVersion     int    `json:"latestVersion"`
+	StagingVersion    *int   `json:"stagingVersion"`
+	ProductionVersion *int   `json:"productionVersion"`
+}
+
+// CreatePropertyRequest is the body of a property creation request.
+type CreatePropertyRequest struct {
+	ProductID    string     `json:"productId"`
+	PropertyName string     `json:"propertyName"`
+	RuleFormat   string     `json:"ruleFormat,omitempty"`
+	CloneFrom    *CloneFrom `json:"cloneFrom,omitempty"`
+}
+
+// CloneFrom identifies the property version a new property is cloned from.
+type CloneFrom struct {
+	PropertyID           string `json:"propertyId"`
+	Version              int    `json:"version"`
+	CopyHostnames        bool   `json:"copyHostnames,omitempty"`
+	CloneFromVersionEtag string `json:"cloneFromVersionEtag,omitempty"`
+}
+
+// Version is a version of a property.
+type Version struct {
+	PropertyVersion  int    `json:"propertyVersion"`
+	UpdatedByUser    string `json:"updatedByUser"`
+	UpdatedDate      string `json:"updatedDate"`
+	ProductionStatus string `json:"productionStatus"`
+	StagingStatus    string `json:"stagingStatus"`
+	Etag             string `json:"etag"`
+	ProductID        string `json:"productId"`
+	RuleFormat       string `json:"ruleFormat,omitempty"`
+	Note             string `json:"note,omitempty"`
+}
+
+// Hostname maps a property hostname to an edge hostname.
+type Hostname struct {
+	CnameType      string `json:"cnameType"`
+	CnameFrom      string `json:"cnameFrom"`
+	CnameTo        string `json:"cnameTo,omitempty"`
+	EdgeHostnameID string `json:"edgeHostnameId,omitempty"`
+}
+
+type propertiesResponse struct {
+	Properties struct {
+		Items []Property `json:"items"`
+	} `json:"properties"`
+}
+
+type versionsResponse struct {
+	Versions struct {
+		Items []Version `json:"items"`
+	} `json:"versions"`
+}
+
+type hostnamesResponse struct {
+	Hostnames struct {
+		Items []Hostname `json:"items"`
+	} `json:"hostnames"`
+}
+
+// Properties lists the properties in contractID and groupID.
+func (c *Client) Properties(ctx context.Context, contractID, groupID string) ([]Property, error) {
+	res, err := get[propertiesResponse](ctx, c, "/papi/v1/properties", scope(contractID, groupID))
+	return res.Properties.Items, err
+}
+
+// Property returns the property propertyID.
+func (c *Client) Property(ctx context.Context, contractID, groupID, propertyID string) (*Property, error) {
+	res, err := get[propertiesResponse](ctx, c, propertyPath(propertyID), scope(contractID, groupID))
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Properties.Items) == 0 {
+		return nil, fmt.Errorf("Property %s not found", propertyID)
+	}
+	return &res.Properties.Items[0], nil
+}
+
+// CreateProperty creates a property in contractID and groupID and returns its ID.
+func (c *Client) CreateProperty(ctx context.Context, contractID, groupID string, req CreatePropertyRequest) (string, error) {
+	res, err := send[struct {
+		PropertyLink string `json:"propertyLink"`
+	}](ctx, c, "POST", "/papi/v1/properties", scope(contractID, groupID), req)
+	if err != nil {
+		return "", err
+	}
+	return linkID(res.PropertyLink), nil
+}
+
+// RemoveProperty deletes the property propertyID. Properties with active versions cannot be removed.
+func (c *Client) RemoveProperty(ctx context.Context, contractID, groupID, propertyID string) error {
+	_, err := send[struct{}](ctx, c, "DELETE", propertyPath(propertyID), scope(contractID, groupID), nil)
+	return err
+}
+
+// Versions lists the versions of the property propertyID.
+func (c *Client) Versions(ctx context.Context, contractID, groupID, propertyID string) ([]Version, error) {
+	res, err := get[versionsResponse](ctx, c, propertyPath(propertyID)+"/versions", scope(contractID, groupID))
+	return res.Versions.Items, err
+}
+
+// Version returns version of the property propertyID.
+func (c *Client) Version(ctx context.Context, contractID, groupID, propertyID string, version int) (*Version, error) {
+	return c.version(ctx, versionPath(propertyID, version), scope(contractID, groupID))
+}
+
+// LatestVersion returns the latest version of the property propertyID, or the latest version
+// active on network if network is not empty.
+func (c *Client) LatestVersion(ctx context.Context, contractID, groupID, propertyID string, network Network) (*Version, error) {
+	query := scope(contractID, groupID)
+	if network != "" {
+		query.Set("activatedOn", string(network))
+	}
+	return c.version(ctx, propertyPath(propertyID)+"/versions/latest", query)
+}
+
+// CreateVersion creates a new version of the property propertyID from version fromVersion and returns
+// its number. fromEtag, if not empty, makes the request fail if fromVersion has changed.
+func (c *Client) CreateVersion(ctx context.Context, contractID, groupID, propertyID string, fromVersion int, fromEtag string) (int, error) {
+	body := map[string]interface{}{"createFromVersion": fromVersion}
+	if fromEtag != "" {
+		body["createFromVersionEtag"] = fromEtag
+	}
+
+	res, err := send[struct {
+		VersionLink string `json:"versionLink"`
+	}](ctx, c, "POST", propertyPath(propertyID)+"/versions", scope(contractID, groupID), body)
+	if err != nil {
+		return 0, err
+	}
+
+	var version int
+	if _, err := fmt.Sscan(linkID(res.VersionLink), &version); err != nil {
+		return 0, fmt.Errorf("Unexpected version link %q", res.VersionLink)
+	}
+	return version, nil
+}
+
+// Hostnames lists the hostnames of version of the property propertyID.
+func (c *Client) Hostnames(ctx context.Context, contractID, groupID, propertyID string, version int) ([]Hostname, error) {
+	res, err := get[hostnamesResponse](ctx, c, versionPath(propertyID, version)+"/hostnames", scope(contractID, groupID))
+	return res.Hostnames.Items, err
+}
+
+// UpdateHostnames replaces the hostnames of version of the property propertyID.
+func (c *Client) UpdateHostnames(ctx context.Context, contractID, groupID, propertyID string, version int, hostnames []Hostname) ([]Hostname, error) {
+	if hostnames == nil {
+		hostnames = []Hostname{}
+	}
+	res, err := send[hostnamesResponse](ctx, c, "PUT", versionPath(propertyID, version)+"/hostnames", scope(contractID, groupID), hostnames)
+	return res.Hostnames.Items, err
+}
+
+func (c *Client) version(ctx context.Context, urlStr string, query url.Values) (*Version, error) {
+	res, err := get[versionsResponse](ctx, c, urlStr, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Versions.Items) == 0 {
+		return nil, fmt.Errorf("Property version not found")
+	}
+	return &res.Versions.Items[0], nil
+}
+
+func propertyPath(propertyID string) string {
+	return "/papi/v1/properties/" + url.PathEscape(propertyID)
+}
+
+func versionPath(propertyID string, version int) string {
+	return fmt.Sprintf("%s/versions/%d", propertyPath(propertyID), version)
+}
@@ -0,0 +1,202 @@
+package papi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/internal/jsonobject"
+)
+
+// RuleTree is the rule tree of a property version.
+type RuleTree struct {
+	AccountID       string        `json:"accountId"`
+	ContractID      string        `json:"contractId"`
+	GroupID         string        `json:"groupId"`
+	PropertyID      string        `json:"propertyId"`
+	PropertyVersion int           `json:"propertyVersion"`
+	Etag            string        `json:"etag"`
+	RuleFormat      string        `json:"ruleFormat"`
+	Comments        string        `json:"comments"`
+	Rules           Rule          `json:"rules"`
+	Errors          []RuleMessage `json:"errors"`
+	Warnings        []RuleMessage `json:"warnings"`
+
+	meta jsonobject.Meta
+}
+
+// RuleMessage is a validation error or warning reported for a rule tree.
+type RuleMessage struct {
+	Type          string `json:"type"`
+	Title         string `json:"title"`
+	Detail        string `json:"detail"`
+	ErrorLocation string `json:"errorLocation"`
+	BehaviorName  string `json:"behaviorName"`
+}
+
+// Rule is a rule of a rule tree: behaviors applied when its criteria match, and child rules.
+type Rule struct {
+	Name                string      `json:"name"`
+	Comments            string      `json:"comments"`
+	UUID                string      `json:"uuid"`
+	TemplateUUID        string      `json:"templateUuid"`
+	CriteriaMustSatisfy string      `json:"criteriaMustSatisfy"`
+	Criteria            []Criterion `json:"criteria"`
+	Behaviors           []Behavior  `json:"behaviors"`
+	Children            []Rule      `json:"children"`
+	Variables           []Variable  `json:"variables"`
+	Options             Options     `json:"options"`
+
+	meta jsonobject.Meta
+}
+
+// Behavior is a behavior of a rule, e.g. "origin" or "caching".
+type Behavior struct {
+	Name         string  `json:"name"`
+	Options      Options `json:"options"`
+	UUID         string  `json:"uuid"`
+	TemplateUUID string  `json:"templateUuid"`
+	Locked       bool    `json:"locked"`
+
+	meta jsonobject.Meta
+}
+
+// Criterion is a match criterion of a rule; it has the same shape as a Behavior.
+type Criterion = Behavior
+
+// Variable is a user defined variable of the default rule.
+type Variable struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+	Hidden      bool   `json:"hidden"`
+	Sensitive   bool   `json:"sensitive"`
+
+	meta jsonobject.Meta
+}
+
+// Options holds behavior, criterion or rule options. Numbers are kept as json.Number, so they
+// are written back exactly as read.
+type Options map[string]interface{}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (o *Options) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var options map[string]interface{}
+	if err := dec.Decode(&options); err != nil {
+		return err
+	}
+	*o = options
+	return nil
+}
+
+type (
+	ruleTreeAlias RuleTree
+	ruleAlias     Rule
+	behaviorAlias Behavior
+	variableAlias Variable
+)
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *RuleTree) UnmarshalJSON(data []byte) error {
+	return t.meta.Unmarshal(data, (*ruleTreeAlias)(t))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t RuleTree) MarshalJSON() ([]byte, error) {
+	return t.meta.Marshal((*ruleTreeAlias)(&t), "rules")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	return r.meta.Unmarshal(data, (*ruleAlias)(r))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r Rule) MarshalJSON() ([]byte, error) {
+	return r.meta.Marshal((*ruleAlias)(&r), "name")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Behavior) UnmarshalJSON(data []byte) error {
+	return b.meta.Unmarshal(data, (*behaviorAlias)(b))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b Behavior) MarshalJSON() ([]byte, error) {
+	return b.meta.Marshal((*behaviorAlias)(&b), "name", "options")
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *Variable) UnmarshalJSON(data []byte) error {
+	return v.meta.Unmarshal(data, (*variableAlias)(v))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v Variable) MarshalJSON() ([]byte, error) {
+	return v.meta.Marshal((*variableAlias)(&v), "name", "value", "description", "hidden", "sensitive")
+}
+
+// Extra returns the fields of the rule that have no typed counterpart, as read from the API.
+func (r *Rule) Extra() map[string]json.RawMessage {
+	return r.meta.Extra()
+}
+
+// Behavior returns the first behavior of the rule named name, or nil.
+func (r *Rule) Behavior(name string) *Behavior {
+	for i := range r.Behaviors {
+		if r.Behaviors[i].Name == name {
+			return &r.Behaviors[i]
+		}
+	}
+	return nil
+}
+
+// Walk calls fn for the rule and all its descendants, depth first. path holds the names of the
+// ancestors of each rule. Walk stops at the first error.
+func (r *Rule) Walk(fn func(rule *Rule, path []string) error) error {
+	return r.walk(nil, fn)
+}
+
+func (r *Rule) walk(path []string, fn func(rule *Rule, path []string) error) error {
+	if err := fn(r, path); err != nil {
+		return err
+	}
+	path = append(path, r.Name)
+	for i := range r.Children {
+		if err := r.Children[i].walk(path, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rules returns the rule tree of version of the property propertyID.
+func (c *Client) Rules(ctx context.Context, contractID, groupID, propertyID string, version int) (*RuleTree, error) {
+	return get[*RuleTree](ctx, c, versionPath(propertyID, version)+"/rules", scope(contractID, groupID))
+}
+
+// UpdateRules replaces the rule tree of version of the property propertyID with tree. The request
+// is made conditional on tree.Etag, so it fails if the rules changed since tree was read. The
+// updated tree is returned, including any validation errors and warnings.
+func (c *Client) UpdateRules(ctx context.Context, contractID, groupID, propertyID string, version int, tree *RuleTree) (*RuleTree, error) {
+	req, err := c.newRequest("PUT", versionPath(propertyID, version)+"/rules", scope(contractID, groupID), tree)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleFormat := tree.RuleFormat
+	if ruleFormat == "" {
+		ruleFormat = "latest"
+	}
+	req.Header.Set("Content-Type", "application/vnd.akamai.papirules."+ruleFormat+"+json")
+	if tree.Etag != "" {
+		req.Header.Set("If-Match", tree.Etag)
+	}
+
+	updated, _, err := edgegrid.DoJSON[*RuleTree](ctx, c.client, req)
+	return updated, err
+}
@@ -0,0 +1,90 @@
+package papi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/edgegridtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleTreeRoundTrip(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/rules.json")
+	assert.NoError(t, err)
+
+	tree := RuleTree{}
+	assert.NoError(t, json.Unmarshal(data, &tree))
+	assert.Equal(t, "v2023-01-05", tree.RuleFormat)
+	assert.Equal(t, "Version 3", tree.Comments)
+	assert.Len(t, tree.Rules.Children, 1)
+	assert.Equal(t, json.Number("0.75"), tree.Rules.Behavior("origin").Options["ratio"])
+	assert.Contains(t, tree.Rules.Extra(), "customOverride")
+
+	encoded, err := json.Marshal(tree)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(data), string(encoded))
+	assert.Contains(t, string(encoded), "12345678901234567890")
+}
+
+func TestRuleTreeModify(t *testing.T) {
+	data, _ := ioutil.ReadFile("testdata/rules.json")
+	tree := RuleTree{}
+	assert.NoError(t, json.Unmarshal(data, &tree))
+
+	tree.Rules.Behavior("origin").Options["hostname"] = "new-origin.example.com"
+	tree.Rules.Children = append(tree.Rules.Children, Rule{
+		Name:      "Redirect",
+		Behaviors: []Behavior{{Name: "redirect", Options: Options{"responseCode": 301}}},
+	})
+
+	encoded, _ := json.Marshal(tree)
+	modified := RuleTree{}
+	assert.NoError(t, json.Unmarshal(encoded, &modified))
+	assert.Equal(t, "new-origin.example.com", modified.Rules.Behavior("origin").Options["hostname"])
+	assert.Equal(t, json.Number("443"), modified.Rules.Behavior("origin").Options["httpsPort"])
+
+	added, _ := json.Marshal(modified.Rules.Children[1])
+	assert.JSONEq(t, `{"name":"Redirect","behaviors":[{"name":"redirect","options":{"responseCode":301}}]}`, string(added))
+
+	var names []string
+	tree.Rules.Walk(func(rule *Rule, path []string) error {
+		names = append(names, rule.Name)
+		return nil
+	})
+	assert.Equal(t, []string{"default", "Compress Text Content", "Redirect"}, names)
+
+	stop := errors.New("stop")
+	assert.Equal(t, stop, tree.Rules.Walk(func(rule *Rule, path []string) error { return stop }))
+}
+
+func TestRulesGetUpdate(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	assert.NoError(t, s.HandleFixture("GET /papi/v1/properties/prp_173136/versions/3/rules", http.StatusOK, "testdata/rules.json"))
+	s.HandleFunc("PUT /papi/v1/properties/prp_173136/versions/3/rules", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	})
+
+	c := New(s.Client())
+	ctx := context.Background()
+
+	tree, err := c.Rules(ctx, "ctr_K-0N7RAK7", "grp_15225", "prp_173136", 3)
+	assert.NoError(t, err)
+
+	updated, err := c.UpdateRules(ctx, "ctr_K-0N7RAK7", "grp_15225", "prp_173136", 3, tree)
+	assert.NoError(t, err)
+	assert.Equal(t, tree.Etag, updated.Etag)
+
+	req := s.Requests()[1]
+	assert.Equal(t, "application/vnd.akamai.papirules.v2023-01-05+json", req.Header.Get("Content-Type"))
+	assert.Equal(t, "a872de3bb59e1ca7e5e6a8e1a0a2a6d1", req.Header.Get("If-Match"))
+	assert.Equal(t, "ctr_K-0N7RAK7", req.Query.Get("contractId"))
+
+	fixture, _ := ioutil.ReadFile("testdata/rules.json")
+	assert.JSONEq(t, string(fixture), string(req.Body))
+}
@@ -0,0 +1,198 @@
+package edgegrid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const defaultPollInterval = 5 * time.Second
+
+// PollProgress describes one status request made by a Poller.
+type PollProgress struct {
+	// Attempt is the number of status requests made so far, starting at 1.
+	Attempt int
+
+	// Elapsed is the time since polling started.
+	Elapsed time.Duration
+
+	// Response is the status response. Its body is buffered and can be decoded with BodyJSON.
+	Response *Response
+
+	// Next is the delay before the next status request, zero once polling is over.
+	Next time.Duration
+}
+
+// Poller polls the status URL of a long-running operation, such as a property activation,
+// until a caller defined terminal state is reached.
+type Poller struct {
+	client *Client
+
+	// URL is the status URL, relative to the Client's BaseURL.
+	URL string
+
+	// Done reports whether the status response describes a terminal state. An error stops polling.
+	Done func(res *Response) (bool, error)
+
+	// Interval is the delay between status requests, and the shortest one: a Retry-After header
+	// or pingAfterSeconds field can only lengthen it, so that a zero hint does not poll in a loop.
+	Interval time.Duration
+
+	// MaxInterval caps the delay between status requests when greater than zero.
+	MaxInterval time.Duration
+
+	// Progress is called after every status request, if set.
+	Progress func(PollProgress)
+}
+
+// NewPoller creates a Poller for statusURL. done reports whether a status response is terminal.
+func (c *Client) NewPoller(statusURL string, done func(res *Response) (bool, error)) *Poller {
+	return &Poller{
+		client:   c,
+		URL:      statusURL,
+		Done:     done,
+		Interval: defaultPollInterval,
+	}
+}
+
+// Poll requests the status URL until Done reports a terminal state and returns that response.
+// 429 and 503 responses are retried after their Retry-After delay; other non-2xx responses stop
+// polling with an *Error. Polling stops with ctx.Err() when ctx is done.
+func (p *Poller) Poll(ctx context.Context) (*Response, error) {
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		req, err := p.client.NewRequest("GET", p.URL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+
+		done := false
+		res, body, err := p.client.Send(ctx, req)
+		if err != nil {
+			var apiErr *Error
+			if !errors.As(err, &apiErr) || !retryable(apiErr.StatusCode) {
+				return res, err
+			}
+		} else {
+			done, err = p.Done(res)
+			if err != nil {
+				return res, err
+			}
+		}
+
+		wait := time.Duration(0)
+		if !done {
+			wait = p.delay(res, body)
+		}
+		if p.Progress != nil {
+			p.Progress(PollProgress{
+				Attempt:  attempt,
+				Elapsed:  time.Since(start),
+				Response: res,
+				Next:     wait,
+			})
+		}
+		if done {
+			return res, nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return res, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// delay returns the wait before the next status request, honoring Retry-After and pingAfterSeconds
+// when they are longer than Interval.
+func (p *Poller) delay(res *Response, body []byte) time.Duration {
+	wait := p.Interval
+	if wait <= 0 {
+		wait = defaultPollInterval
+	}
+
+	if retryAfter, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+		if retryAfter > wait {
+			wait = retryAfter
+		}
+	} else {
+		var hint struct {
+			PingAfterSeconds *int `json:"pingAfterSeconds"`
+		}
+		if json.Unmarshal(body, &hint) == nil && hint.PingAfterSeconds != nil {
+			if pingAfter := time.Duration(*hint.PingAfterSeconds) * time.Second; pingAfter > wait {
+				wait = pingAfter
+			}
+		}
+	}
+
+	if p.MaxInterval > 0 && wait > p.MaxInterval {
+		wait = p.MaxInterval
+	}
+	return wait
+}
+
+func retryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// StatusURL extracts the status URL of a long-running operation from the response that started it:
+// the Location header, or a checkStatusLink, statusLink, progressUri or _links.self.href field.
+func StatusURL(res *Response) (string, error) {
+	if location := res.Header.Get("Location"); location != "" {
+		return location, nil
+	}
+
+	body, err := res.Bytes()
+	if err != nil {
+		return "", err
+	}
+
+	var links struct {
+		CheckStatusLink string `json:"checkStatusLink"`
+		StatusLink      string `json:"statusLink"`
+		ProgressURI     string `json:"progressUri"`
+		Links           struct {
+			Self struct {
+				Href string `json:"href"`
+			} `json:"self"`
+		} `json:"_links"`
+	}
+	if err := json.Unmarshal(body, &links); err != nil {
+		return "", err
+	}
+
+	for _, link := range []string{links.CheckStatusLink, links.StatusLink, links.ProgressURI, links.Links.Self.Href} {
+		if link != "" {
+			return link, nil
+		}
+	}
+
+	return "", errors.New("No status link found in response")
+}
@@ -0,0 +1,119 @@
+package edgegrid_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/edgegridtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type purgeStatus struct {
+	PurgeStatus      string `json:"purgeStatus"`
+	PingAfterSeconds int    `json:"pingAfterSeconds"`
+}
+
+func purgeDone(res *edgegrid.Response) (bool, error) {
+	status := purgeStatus{}
+	if err := res.BodyJSON(&status); err != nil {
+		return false, err
+	}
+	return status.PurgeStatus == "Done", nil
+}
+
+func TestPoller(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+
+	var calls int32
+	s.HandleFunc("GET /ccu/v2/purges/abc", func(w http.ResponseWriter, r *http.Request) {
+		status := "In-Progress"
+		if atomic.AddInt32(&calls, 1) >= 3 {
+			status = "Done"
+		}
+		json.NewEncoder(w).Encode(purgeStatus{PurgeStatus: status, PingAfterSeconds: 0})
+	})
+	s.Script("GET /ccu/v2/purges/abc", edgegridtest.RateLimit(0))
+
+	client := s.Client()
+	poller := client.NewPoller("/ccu/v2/purges/abc", purgeDone)
+	poller.Interval = 10 * time.Millisecond
+
+	var progress []edgegrid.PollProgress
+	poller.Progress = func(p edgegrid.PollProgress) {
+		progress = append(progress, p)
+	}
+
+	res, err := poller.Poll(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, `{"purgeStatus":"Done","pingAfterSeconds":0}`+"\n", res.String())
+
+	if assert.Len(t, progress, 4) {
+		assert.Equal(t, http.StatusTooManyRequests, progress[0].Response.StatusCode)
+		assert.Equal(t, 4, progress[3].Attempt)
+		assert.Equal(t, time.Duration(0), progress[3].Next)
+
+		// Retry-After: 0 and pingAfterSeconds 0 do not shorten the interval
+		for _, p := range progress[:3] {
+			assert.Equal(t, 10*time.Millisecond, p.Next)
+		}
+	}
+}
+
+func TestPollerError(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+
+	poller := s.Client().NewPoller("/ccu/v2/purges/missing", purgeDone)
+	_, err := poller.Poll(context.Background())
+	assert.IsType(t, &edgegrid.Error{}, err)
+}
+
+func TestPollerDeadline(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleJSON("GET /ccu/v2/purges/abc", http.StatusOK, purgeStatus{PurgeStatus: "In-Progress", PingAfterSeconds: 60})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	poller := s.Client().NewPoller("/ccu/v2/purges/abc", purgeDone)
+	_, err := poller.Poll(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Len(t, s.Requests(), 1)
+}
+
+func TestStatusURL(t *testing.T) {
+	matrix := []struct {
+		header http.Header
+		body   string
+		url    string
+	}{
+		{http.Header{"Location": {"/papi/v1/properties/prp_1/activations/atv_1"}}, `{}`, "/papi/v1/properties/prp_1/activations/atv_1"},
+		{http.Header{}, `{"purgeId":"abc","checkStatusLink":"/ccu/v2/purges/abc"}`, "/ccu/v2/purges/abc"},
+		{http.Header{}, `{"_links":{"self":{"href":"/diagnostic-tools/v2/requests/1"}}}`, "/diagnostic-tools/v2/requests/1"},
+	}
+	for _, tt := range matrix {
+		s := edgegridtest.NewServer()
+		s.HandleFunc("POST /start", func(w http.ResponseWriter, r *http.Request) {
+			for k, v := range tt.header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(tt.body))
+		})
+
+		res, err := s.Client().PostJSON("/start", map[string]string{})
+		assert.NoError(t, err)
+		url, err := edgegrid.StatusURL(res)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.url, url)
+		s.Close()
+	}
+}
@@ -0,0 +1,63 @@
+package reporting
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteCSV runs req over its whole time range and streams the rows to w as CSV, with a header
+// line of column names. Rows of each window are written as soon as the windows before it are
+// done, and at most Concurrency windows are fetched or held at a time, so memory use is bounded
+// by the concurrency, not the time range.
+func (c *Client) WriteCSV(ctx context.Context, w io.Writer, req Request) error {
+	cw := csv.NewWriter(w)
+
+	var cols []string
+	err := c.each(ctx, req, func(p *page) error {
+		if len(p.Data) == 0 {
+			return nil
+		}
+		if cols == nil {
+			cols = columns(p, req.Metrics)
+			if err := cw.Write(cols); err != nil {
+				return err
+			}
+		}
+
+		record := make([]string, len(cols))
+		for _, row := range p.Data {
+			for i, col := range cols {
+				record[i] = formatValue(row[col])
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return fmt.Sprint(v)
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
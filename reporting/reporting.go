@@ -0,0 +1,360 @@
+// Package reporting provides a client for the Akamai Reporting API.
+//
+// The API limits the time range of a single report request depending on the
+// interval of the data. Run accepts any range: it splits the range into
+// windows the API allows, requests them with bounded concurrency and merges
+// the rows in chronological order, as a Result, a slice of a caller defined
+// type (Fetch) or a CSV stream (WriteCSV).
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// Interval is the granularity of report data.
+type Interval string
+
+const (
+	FiveMinutes Interval = "FIVE_MINUTES"
+	Hour        Interval = "HOUR"
+	Day         Interval = "DAY"
+	Week        Interval = "WEEK"
+	Month       Interval = "MONTH"
+)
+
+// MaxWindow is the longest time range of a single request for each interval but Month, whose
+// windows are MaxMonths calendar months.
+var MaxWindow = map[Interval]time.Duration{
+	FiveMinutes: 24 * time.Hour,
+	Hour:        7 * 24 * time.Hour,
+	Day:         90 * 24 * time.Hour,
+	Week:        52 * 7 * 24 * time.Hour,
+}
+
+// MaxMonths is the number of calendar months of a single request for the Month interval.
+var MaxMonths = 24
+
+// intervalLength is the length of the data buckets of each interval but Month. Weeks start on
+// Monday, as time.Truncate aligns them.
+var intervalLength = map[Interval]time.Duration{
+	FiveMinutes: 5 * time.Minute,
+	Hour:        time.Hour,
+	Day:         24 * time.Hour,
+	Week:        7 * 24 * time.Hour,
+}
+
+// Request describes a report to run.
+type Request struct {
+	// Report and Version identify the report, e.g. "todaytraffic-by-time" version 1.
+	Report  string
+	Version int
+
+	// Start and End delimit the data, End excluded. Start must be at the start of a bucket of
+	// the interval in UTC, e.g. midnight for Day or the first of a month for Month, so that no
+	// bucket is split across windows.
+	Start    time.Time
+	End      time.Time
+	Interval Interval
+
+	ObjectType string
+	ObjectIDs  []string
+	Metrics    []string
+	Filters    map[string][]string
+
+	// Window overrides MaxWindow for the interval when greater than zero. It must be a whole
+	// number of intervals and cannot be used with Month.
+	Window time.Duration
+}
+
+// Metadata describes the data returned for one window.
+type Metadata struct {
+	Name              string   `json:"name"`
+	Version           string   `json:"version"`
+	OutputType        string   `json:"outputType"`
+	GroupBy           []string `json:"groupBy"`
+	Interval          string   `json:"interval"`
+	Start             string   `json:"start"`
+	End               string   `json:"end"`
+	AvailableDataEnds string   `json:"availableDataEnds"`
+	RowCount          int      `json:"rowCount"`
+}
+
+// Row is a data row keyed by column. Numbers are kept as json.Number.
+type Row map[string]interface{}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *Row) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var row map[string]interface{}
+	if err := dec.Decode(&row); err != nil {
+		return err
+	}
+	*r = row
+	return nil
+}
+
+// Result is the merged data of every window of a report.
+type Result struct {
+	// Metadata holds the metadata of each window, in order.
+	Metadata []Metadata
+
+	// Columns are the group by columns followed by the metrics.
+	Columns []string
+
+	Rows []Row
+}
+
+type page struct {
+	Metadata Metadata `json:"metadata"`
+	Data     []Row    `json:"data"`
+}
+
+type window struct {
+	start, end time.Time
+}
+
+// Client is a Reporting API client.
+type Client struct {
+	client *edgegrid.Client
+
+	// Concurrency is the maximum number of window requests in flight.
+	Concurrency int
+}
+
+// New creates a Reporting client sending requests with client.
+func New(client *edgegrid.Client) *Client {
+	return &Client{client: client, Concurrency: 4}
+}
+
+// Run runs req over its whole time range and returns the merged rows.
+func (c *Client) Run(ctx context.Context, req Request) (*Result, error) {
+	result := &Result{}
+	err := c.each(ctx, req, func(p *page) error {
+		result.Metadata = append(result.Metadata, p.Metadata)
+		if result.Columns == nil && len(p.Data) > 0 {
+			result.Columns = columns(p, req.Metrics)
+		}
+		result.Rows = append(result.Rows, p.Data...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Fetch runs req over its whole time range and decodes each row into a T.
+func Fetch[T any](ctx context.Context, c *Client, req Request) ([]T, error) {
+	var rows []T
+	err := c.each(ctx, req, func(p *page) error {
+		for _, row := range p.Data {
+			data, err := json.Marshal(row)
+			if err != nil {
+				return err
+			}
+			var v T
+			if err := json.Unmarshal(data, &v); err != nil {
+				return fmt.Errorf("Unable to decode row: %s", err)
+			}
+			rows = append(rows, v)
+		}
+		return nil
+	})
+	return rows, err
+}
+
+// Windows splits the time range of req into the windows requested by Run.
+func Windows(req Request) ([]time.Time, error) {
+	windows, err := split(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var bounds []time.Time
+	for _, w := range windows {
+		bounds = append(bounds, w.start)
+	}
+	if len(windows) > 0 {
+		bounds = append(bounds, windows[len(windows)-1].end)
+	}
+	return bounds, nil
+}
+
+func split(req Request) ([]window, error) {
+	if !req.Start.Before(req.End) {
+		return nil, fmt.Errorf("Start %s is not before end %s", req.Start, req.End)
+	}
+	if req.Interval == Month {
+		return splitMonths(req)
+	}
+
+	length, ok := intervalLength[req.Interval]
+	if !ok {
+		return nil, fmt.Errorf("Unknown interval %q", req.Interval)
+	}
+	if !req.Start.Equal(req.Start.Truncate(length)) {
+		return nil, fmt.Errorf("Start %s is not at the start of a %s interval", req.Start.UTC().Format(time.RFC3339), req.Interval)
+	}
+	max := req.Window
+	if max <= 0 {
+		max = MaxWindow[req.Interval]
+	} else if max%length != 0 {
+		return nil, fmt.Errorf("Window %s is not a whole number of %s intervals", max, req.Interval)
+	}
+
+	var windows []window
+	for start := req.Start; start.Before(req.End); {
+		end := start.Add(max)
+		if end.After(req.End) {
+			end = req.End
+		}
+		windows = append(windows, window{start, end})
+		start = end
+	}
+	return windows, nil
+}
+
+// splitMonths splits the time range of req on calendar months, as months differ in length.
+func splitMonths(req Request) ([]window, error) {
+	if req.Window > 0 {
+		return nil, fmt.Errorf("Window cannot be used with the %s interval", Month)
+	}
+	start := req.Start.UTC()
+	if start.Day() != 1 || !start.Equal(start.Truncate(24*time.Hour)) {
+		return nil, fmt.Errorf("Start %s is not at the start of a month", start.Format(time.RFC3339))
+	}
+
+	var windows []window
+	for n := 0; start.Before(req.End); n += MaxMonths {
+		end := req.Start.UTC().AddDate(0, n+MaxMonths, 0)
+		if end.After(req.End) {
+			end = req.End
+		}
+		windows = append(windows, window{start, end})
+		start = end
+	}
+	return windows, nil
+}
+
+// each requests the windows of req with bounded concurrency and calls fn with each page in
+// chronological order. The first error cancels the requests still running.
+func (c *Client) each(ctx context.Context, req Request, fn func(*page) error) error {
+	windows, err := split(req)
+	if err != nil {
+		return err
+	}
+
+	// Deferred in this order so that requests still running are cancelled before waiting for them.
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	concurrency := c.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	type outcome struct {
+		page *page
+		err  error
+	}
+	outcomes := make([]chan outcome, len(windows))
+	for i := range outcomes {
+		outcomes[i] = make(chan outcome, 1)
+	}
+
+	sem := make(chan struct{}, concurrency)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i, w := range windows {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				for ; i < len(windows); i++ {
+					outcomes[i] <- outcome{err: ctx.Err()}
+				}
+				return
+			}
+
+			wg.Add(1)
+			go func(i int, w window) {
+				defer wg.Done()
+				p, err := c.fetch(ctx, req, w)
+				outcomes[i] <- outcome{p, err}
+			}(i, w)
+		}
+	}()
+
+	// A window keeps its semaphore slot until its page has been consumed, so a slow window holds
+	// back the later ones instead of letting their pages pile up in memory.
+	for i := range windows {
+		o := <-outcomes[i]
+		if o.err != nil {
+			return fmt.Errorf("Unable to fetch window %s - %s: %w", windows[i].start.Format(time.RFC3339), windows[i].end.Format(time.RFC3339), o.err)
+		}
+		err := fn(o.page)
+		<-sem
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) fetch(ctx context.Context, req Request, w window) (*page, error) {
+	query := url.Values{}
+	query.Set("start", w.start.UTC().Format(time.RFC3339))
+	query.Set("end", w.end.UTC().Format(time.RFC3339))
+	query.Set("interval", string(req.Interval))
+
+	version := req.Version
+	if version == 0 {
+		version = 1
+	}
+	path := fmt.Sprintf("/reporting-api/v1/reports/%s/versions/%d/report-data?%s", url.PathEscape(req.Report), version, query.Encode())
+
+	body := struct {
+		ObjectType string              `json:"objectType,omitempty"`
+		ObjectIDs  []string            `json:"objectIds,omitempty"`
+		Metrics    []string            `json:"metrics,omitempty"`
+		Filters    map[string][]string `json:"filters,omitempty"`
+	}{req.ObjectType, req.ObjectIDs, req.Metrics, req.Filters}
+
+	p, _, err := edgegrid.SendJSON[interface{}, *page](ctx, c.client, "POST", path, body)
+	return p, err
+}
+
+// columns returns the group by columns of p followed by metrics, or by the other columns of
+// its first row in alphabetical order when metrics is empty.
+func columns(p *page, metrics []string) []string {
+	cols := append([]string(nil), p.Metadata.GroupBy...)
+	if len(metrics) > 0 {
+		return append(cols, metrics...)
+	}
+
+	grouped := map[string]bool{}
+	for _, col := range cols {
+		grouped[col] = true
+	}
+	var rest []string
+	for col := range p.Data[0] {
+		if !grouped[col] {
+			rest = append(rest, col)
+		}
+	}
+	sort.Strings(rest)
+	return append(cols, rest...)
+}
@@ -0,0 +1,233 @@
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/edgegridtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// newServer serves an hourly report with one row per hour of the requested window, counting the
+// requests in flight to check the concurrency bound.
+func newServer(t *testing.T, inFlight, maxInFlight *int32) *edgegridtest.Server {
+	s := edgegridtest.NewServer()
+	s.HandleFunc("POST /reporting-api/v1/reports/todaytraffic-by-time/versions/1/report-data", func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(inFlight, 1)
+		defer atomic.AddInt32(inFlight, -1)
+		for {
+			max := atomic.LoadInt32(maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(maxInFlight, max, current) {
+				break
+			}
+		}
+
+		start, err := time.Parse(time.RFC3339, r.URL.Query().Get("start"))
+		assert.NoError(t, err)
+		end, err := time.Parse(time.RFC3339, r.URL.Query().Get("end"))
+		assert.NoError(t, err)
+		assert.Equal(t, "HOUR", r.URL.Query().Get("interval"))
+
+		// Later windows answer first, so merging must restore the order.
+		time.Sleep(time.Duration(end.Sub(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).Hours()/24) * time.Millisecond)
+
+		rows := []map[string]interface{}{}
+		for at := start; at.Before(end); at = at.Add(time.Hour) {
+			rows = append(rows, map[string]interface{}{"startdatetime": at.Format(time.RFC3339), "edgeHits": at.Hour() + 1, "edgeBytes": "12345678901234567890"})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"metadata": Metadata{Name: "todaytraffic-by-time", GroupBy: []string{"startdatetime"}, Start: start.Format(time.RFC3339), End: end.Format(time.RFC3339), RowCount: len(rows)},
+			"data":     rows,
+		})
+	})
+	return s
+}
+
+func request() Request {
+	return Request{
+		Report:     "todaytraffic-by-time",
+		Start:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:        time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		Interval:   Hour,
+		ObjectType: "cpcode",
+		ObjectIDs:  []string{"12345"},
+		Metrics:    []string{"edgeHits", "edgeBytes"},
+	}
+}
+
+func TestWindows(t *testing.T) {
+	bounds, err := Windows(request())
+	assert.NoError(t, err)
+	if assert.Len(t, bounds, 6) {
+		assert.Equal(t, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), bounds[1])
+		assert.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), bounds[5])
+	}
+
+	req := request()
+	req.Window = time.Hour
+	req.End = req.Start.Add(3 * time.Hour)
+	bounds, _ = Windows(req)
+	assert.Len(t, bounds, 4)
+
+	req.Interval = "DECADE"
+	req.Window = 0
+	_, err = Windows(req)
+	assert.Error(t, err)
+
+	req.Interval = Hour
+	req.End = req.Start
+	_, err = Windows(req)
+	assert.Error(t, err)
+
+	req = request()
+	req.Start = req.Start.Add(30 * time.Minute)
+	_, err = Windows(req)
+	assert.EqualError(t, err, "Start 2024-01-01T00:30:00Z is not at the start of a HOUR interval")
+	req.Start = request().Start
+	req.Window = 90 * time.Minute
+	_, err = Windows(req)
+	assert.Error(t, err)
+
+	// 2024-01-01 is a Monday, weeks start on Mondays
+	req = request()
+	req.Interval = Week
+	_, err = Windows(req)
+	assert.NoError(t, err)
+	req.Start = req.Start.AddDate(0, 0, 1)
+	_, err = Windows(req)
+	assert.Error(t, err)
+}
+
+func TestWindowsMonth(t *testing.T) {
+	req := request()
+	req.Interval = Month
+	req.Start = time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	req.End = time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	bounds, err := Windows(req)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+	}, bounds)
+
+	req.Start = time.Date(2020, 3, 15, 0, 0, 0, 0, time.UTC)
+	_, err = Windows(req)
+	assert.EqualError(t, err, "Start 2020-03-15T00:00:00Z is not at the start of a month")
+
+	req.Start = time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	req.Window = 24 * time.Hour
+	_, err = Windows(req)
+	assert.Error(t, err)
+}
+
+func TestRun(t *testing.T) {
+	var inFlight, maxInFlight int32
+	s := newServer(t, &inFlight, &maxInFlight)
+	defer s.Close()
+
+	c := New(s.Client())
+	c.Concurrency = 2
+	result, err := c.Run(context.Background(), request())
+	assert.NoError(t, err)
+
+	assert.Len(t, result.Metadata, 5)
+	assert.Equal(t, []string{"startdatetime", "edgeHits", "edgeBytes"}, result.Columns)
+	if assert.Len(t, result.Rows, 30*24) {
+		for i, row := range result.Rows {
+			assert.Equal(t, request().Start.Add(time.Duration(i)*time.Hour).Format(time.RFC3339), row["startdatetime"])
+		}
+		assert.Equal(t, json.Number("1"), result.Rows[0]["edgeHits"])
+	}
+	assert.True(t, maxInFlight <= 2, "Fail: %d requests in flight", maxInFlight)
+
+	assert.JSONEq(t, `{"objectType":"cpcode","objectIds":["12345"],"metrics":["edgeHits","edgeBytes"]}`, string(s.Requests()[0].Body))
+}
+
+func TestFetch(t *testing.T) {
+	var inFlight, maxInFlight int32
+	s := newServer(t, &inFlight, &maxInFlight)
+	defer s.Close()
+
+	type traffic struct {
+		Start time.Time `json:"startdatetime"`
+		Hits  int       `json:"edgeHits"`
+	}
+
+	req := request()
+	req.End = req.Start.Add(10 * 24 * time.Hour)
+	rows, err := Fetch[traffic](context.Background(), New(s.Client()), req)
+	assert.NoError(t, err)
+	if assert.Len(t, rows, 240) {
+		assert.Equal(t, req.Start.Add(239*time.Hour), rows[239].Start)
+		assert.Equal(t, 24, rows[239].Hits)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var inFlight, maxInFlight int32
+	s := newServer(t, &inFlight, &maxInFlight)
+	defer s.Close()
+
+	req := request()
+	req.End = req.Start.Add(2 * time.Hour)
+	req.Window = time.Hour
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, New(s.Client()).WriteCSV(context.Background(), &buf, req))
+	assert.Equal(t, "startdatetime,edgeHits,edgeBytes\n2024-01-01T00:00:00Z,1,12345678901234567890\n2024-01-01T01:00:00Z,2,12345678901234567890\n", buf.String())
+}
+
+func TestRunError(t *testing.T) {
+	var inFlight, maxInFlight int32
+	s := newServer(t, &inFlight, &maxInFlight)
+	defer s.Close()
+	s.Script("POST /reporting-api/v1/reports/todaytraffic-by-time/versions/1/report-data", edgegridtest.Pass(), edgegridtest.ServerError(http.StatusBadRequest))
+
+	c := New(s.Client())
+	c.Concurrency = 1
+	_, err := c.Run(context.Background(), request())
+	var apiErr *edgegrid.Error
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	}
+	assert.Contains(t, err.Error(), fmt.Sprintf("window %s", "2024-01-08T00:00:00Z"))
+}
+
+func TestEachBound(t *testing.T) {
+	var started int32
+	release := make(chan struct{})
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleFunc("POST /reporting-api/v1/reports/todaytraffic-by-time/versions/1/report-data", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&started, 1)
+		if r.URL.Query().Get("start") == request().Start.Format(time.RFC3339) {
+			<-release
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"metadata": Metadata{}, "data": []map[string]interface{}{}})
+	})
+
+	c := New(s.Client())
+	c.Concurrency = 2
+	done := make(chan error)
+	go func() {
+		done <- c.each(context.Background(), request(), func(*page) error { return nil })
+	}()
+
+	// While the first window is slow, the windows after it are fetched but not consumed, so no
+	// more than Concurrency windows may be started.
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&started))
+
+	close(release)
+	assert.NoError(t, <-done)
+	assert.Equal(t, int32(5), atomic.LoadInt32(&started))
+}
@@ -0,0 +1,256 @@
+package edgegrid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"iter"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Response is the response to a request sent by Client.
+type Response http.Response
+
+// ErrBodyTooLarge is returned when a response body exceeds the size limit set with MaxBodySize.
+var ErrBodyTooLarge = errors.New("Response body exceeds maximum size")
+
+// BodyOption configures how a Response body is read and decoded.
+type BodyOption func(*bodyOptions)
+
+type bodyOptions struct {
+	strict       bool
+	maxSize      int64
+	contentTypes []string
+}
+
+// Strict makes BodyJSON fail on JSON fields that do not exist in the destination.
+func Strict() BodyOption {
+	return func(o *bodyOptions) {
+		o.strict = true
+	}
+}
+
+// MaxBodySize limits the number of body bytes read to n; larger bodies fail with ErrBodyTooLarge.
+func MaxBodySize(n int64) BodyOption {
+	return func(o *bodyOptions) {
+		o.maxSize = n
+	}
+}
+
+// ContentType requires the response Content-Type to be one of types. With no types, any
+// JSON media type (application/json or application/*+json) is accepted.
+func ContentType(types ...string) BodyOption {
+	return func(o *bodyOptions) {
+		o.contentTypes = types
+		if len(types) == 0 {
+			o.contentTypes = []string{"application/json", "+json"}
+		}
+	}
+}
+
+func newBodyOptions(opts []BodyOption) *bodyOptions {
+	o := &bodyOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// bufferedBody is a response body that has been read in full and closed. A body that was
+// too large keeps err, which every later read returns.
+type bufferedBody struct {
+	*bytes.Reader
+	data []byte
+	err  error
+}
+
+func newBufferedBody(data []byte) *bufferedBody {
+	return &bufferedBody{Reader: bytes.NewReader(data), data: data}
+}
+
+func (b *bufferedBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.Reader.Read(p)
+}
+
+func (b *bufferedBody) Close() error {
+	return nil
+}
+
+// Bytes reads the whole body, closes it and returns its contents. The body is buffered, so
+// Bytes, String and BodyJSON can be called any number of times, and Body remains readable.
+// A body exceeding MaxBodySize is discarded and every later read fails with ErrBodyTooLarge.
+func (r *Response) Bytes(opts ...BodyOption) ([]byte, error) {
+	o := newBodyOptions(opts)
+	if buffered, ok := r.Body.(*bufferedBody); ok {
+		if buffered.err != nil {
+			return nil, buffered.err
+		}
+		if o.maxSize > 0 && int64(len(buffered.data)) > o.maxSize {
+			return nil, ErrBodyTooLarge
+		}
+		buffered.Seek(0, io.SeekStart)
+		return buffered.data, nil
+	}
+
+	if r.Body == nil {
+		return nil, nil
+	}
+
+	reader := io.Reader(r.Body)
+	if o.maxSize > 0 {
+		reader = io.LimitReader(r.Body, o.maxSize+1)
+	}
+
+	data, err := ioutil.ReadAll(reader)
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if o.maxSize > 0 && int64(len(data)) > o.maxSize {
+		r.Body = &bufferedBody{Reader: bytes.NewReader(nil), err: ErrBodyTooLarge}
+		return nil, ErrBodyTooLarge
+	}
+
+	r.Body = newBufferedBody(data)
+	return data, nil
+}
+
+// String returns the body as a string, or an empty string if it cannot be read.
+func (r *Response) String() string {
+	data, _ := r.Bytes()
+	return string(data)
+}
+
+// BodyJSON decodes the JSON body into data, which must be a pointer. The body is buffered and
+// closed, see Bytes.
+func (r *Response) BodyJSON(data interface{}, opts ...BodyOption) error {
+	if data == nil {
+		return errors.New("You must pass in an interface{}")
+	}
+
+	o := newBodyOptions(opts)
+	if err := r.checkContentType(o); err != nil {
+		return err
+	}
+
+	body, err := r.Bytes(opts...)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if o.strict {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(data); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("Unexpected data after JSON body")
+	}
+
+	return nil
+}
+
+func (r *Response) checkContentType(o *bodyOptions) error {
+	if len(o.contentTypes) == 0 {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return fmt.Errorf("Invalid Content-Type %q: %s", r.Header.Get("Content-Type"), err)
+	}
+	for _, want := range o.contentTypes {
+		if strings.HasPrefix(want, "+") && strings.HasSuffix(mediaType, want) {
+			return nil
+		}
+		if strings.EqualFold(mediaType, want) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Unexpected Content-Type %q", mediaType)
+}
+
+// Decode streams the elements of a JSON array in the body of r without reading the whole body
+// into memory. With no keys the body must be an array; otherwise keys is the path to the array
+// through nested objects, e.g. Decode[Record](res, "data"). The body is closed when iteration ends.
+func Decode[T any](r *Response, keys ...string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		if r.Body == nil {
+			yield(zero, io.ErrUnexpectedEOF)
+			return
+		}
+		defer r.Body.Close()
+
+		dec := json.NewDecoder(r.Body)
+		if err := seekArray(dec, keys); err != nil {
+			yield(zero, err)
+			return
+		}
+
+		for dec.More() {
+			var item T
+			if err := dec.Decode(&item); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(item, nil) {
+				return
+			}
+		}
+
+		if _, err := dec.Token(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// seekArray advances dec past the opening bracket of the array found at keys.
+func seekArray(dec *json.Decoder, keys []string) error {
+	for _, key := range keys {
+		if err := expectDelim(dec, '{'); err != nil {
+			return err
+		}
+
+		for {
+			if !dec.More() {
+				return fmt.Errorf("Key %q not found in JSON body", key)
+			}
+			token, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if token == key {
+				break
+			}
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+		}
+	}
+
+	return expectDelim(dec, '[')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("Expected %q in JSON body, found %v", delim, token)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package edgegrid
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type closeTracker struct {
+	*strings.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func newTestResponse(contentType, body string) (*Response, *closeTracker) {
+	tracker := &closeTracker{Reader: strings.NewReader(body)}
+	return &Response{
+		StatusCode: 200,
+		Header:     http.Header{"Content-Type": {contentType}},
+		Body:       tracker,
+	}, tracker
+}
+
+type location struct {
+	Name string `json:"name"`
+}
+
+func TestResponseBodyJSONTwice(t *testing.T) {
+	res, tracker := newTestResponse("application/json", `{"name":"Tokyo"}`)
+
+	first := location{}
+	assert.NoError(t, res.BodyJSON(&first))
+	assert.True(t, tracker.closed)
+
+	second := location{}
+	assert.NoError(t, res.BodyJSON(&second))
+	assert.Equal(t, "Tokyo", second.Name)
+	assert.Equal(t, `{"name":"Tokyo"}`, res.String())
+
+	body, err := ioutil.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"Tokyo"}`, string(body))
+
+	assert.Error(t, res.BodyJSON(nil))
+}
+
+func TestResponseBodyJSONStrict(t *testing.T) {
+	res, _ := newTestResponse("application/json", `{"name":"Tokyo","extra":true}`)
+
+	loose := location{}
+	assert.NoError(t, res.BodyJSON(&loose))
+
+	strict := location{}
+	assert.Error(t, res.BodyJSON(&strict, Strict()))
+}
+
+func TestResponseContentType(t *testing.T) {
+	matrix := []struct {
+		contentType string
+		opts        []BodyOption
+		ok          bool
+	}{
+		{"application/json; charset=utf-8", []BodyOption{ContentType()}, true},
+		{"application/problem+json", []BodyOption{ContentType()}, true},
+		{"text/html", []BodyOption{ContentType()}, false},
+		{"text/html", nil, true},
+		{"application/vnd.akamai.cps.enrollments.v11+json", []BodyOption{ContentType("application/vnd.akamai.cps.enrollments.v11+json")}, true},
+		{"application/json", []BodyOption{ContentType("application/vnd.akamai.cps.enrollments.v11+json")}, false},
+	}
+	for _, tt := range matrix {
+		res, _ := newTestResponse(tt.contentType, `{"name":"Tokyo"}`)
+		err := res.BodyJSON(&location{}, tt.opts...)
+		assert.Equal(t, tt.ok, err == nil, "Fail: %s", tt.contentType)
+	}
+}
+
+func TestResponseMaxBodySize(t *testing.T) {
+	res, tracker := newTestResponse("application/json", `{"name":"Tokyo"}`)
+	_, err := res.Bytes(MaxBodySize(4))
+	assert.Equal(t, ErrBodyTooLarge, err)
+	assert.True(t, tracker.closed)
+
+	// The error sticks to the discarded body
+	_, err = res.Bytes()
+	assert.Equal(t, ErrBodyTooLarge, err)
+	var loc location
+	assert.Equal(t, ErrBodyTooLarge, res.BodyJSON(&loc))
+	_, err = ioutil.ReadAll(res.Body)
+	assert.Equal(t, ErrBodyTooLarge, err)
+
+	res, _ = newTestResponse("application/json", `{"name":"Tokyo"}`)
+	body, err := res.Bytes(MaxBodySize(16))
+	assert.NoError(t, err)
+	assert.Len(t, body, 16)
+
+	// The limit also applies to a buffered body
+	_, err = res.Bytes(MaxBodySize(4))
+	assert.Equal(t, ErrBodyTooLarge, err)
+	body, err = res.Bytes()
+	assert.NoError(t, err)
+	assert.Len(t, body, 16)
+}
+
+func TestResponseDecode(t *testing.T) {
+	res, tracker := newTestResponse("application/json", `{"meta":{"n":[1,2]},"data":[{"name":"a"},{"name":"b"},{"name":"c"}]}`)
+
+	var names []string
+	for loc, err := range Decode[location](res, "data") {
+		assert.NoError(t, err)
+		names = append(names, loc.Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+	assert.True(t, tracker.closed)
+
+	res, _ = newTestResponse("application/json", `[{"name":"a"},{"name":"b"}]`)
+	for loc, err := range Decode[location](res) {
+		assert.NoError(t, err)
+		assert.Equal(t, "a", loc.Name)
+		break
+	}
+
+	res, _ = newTestResponse("application/json", `{"other":[]}`)
+	for _, err := range Decode[location](res, "data") {
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,153 @@
+// Package siteshield provides a client for the Akamai Site Shield API.
+//
+// A Site Shield map lists the CIDR blocks of the edge servers allowed to
+// reach an origin. When Akamai proposes a change, the proposed CIDRs must be
+// added to origin firewalls before the map is acknowledged; DiffCIDRs
+// computes the changes to make.
+package siteshield
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang"
+)
+
+// Map is a Site Shield map.
+type Map struct {
+	ID                    int      `json:"id"`
+	RuleName              string   `json:"ruleName"`
+	MapAlias              string   `json:"mapAlias"`
+	Type                  string   `json:"type"`
+	Service               string   `json:"service"`
+	Shared                bool     `json:"shared"`
+	CurrentCidrs          []string `json:"currentCidrs"`
+	ProposedCidrs         []string `json:"proposedCidrs"`
+	Acknowledged          bool     `json:"acknowledged"`
+	AcknowledgedBy        string   `json:"acknowledgedBy,omitempty"`
+	AcknowledgedOn        int64    `json:"acknowledgedOn,omitempty"`
+	AcknowledgeRequiredBy int64    `json:"acknowledgeRequiredBy,omitempty"`
+	LatestTicketID        int      `json:"latestTicketId,omitempty"`
+	McmMapRuleID          int      `json:"mcmMapRuleId,omitempty"`
+	Contacts              []string `json:"contacts,omitempty"`
+}
+
+// MapsResponse is the response of the list endpoint.
+type MapsResponse struct {
+	SiteShieldMaps []Map `json:"siteShieldMaps"`
+}
+
+// CIDRDiff is the difference between the current and proposed CIDRs of a map.
+type CIDRDiff struct {
+	// Added are the proposed CIDRs not currently in the map; allow them before acknowledging.
+	Added []netip.Prefix
+	// Removed are the current CIDRs not in the proposal; they can be removed after acknowledging.
+	Removed []netip.Prefix
+	// Unchanged are the CIDRs in both.
+	Unchanged []netip.Prefix
+}
+
+// Empty reports whether the proposal changes nothing.
+func (d CIDRDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// Diff returns the difference between the current and proposed CIDRs of m.
+func (m *Map) Diff() (CIDRDiff, error) {
+	return DiffCIDRs(m.CurrentCidrs, m.ProposedCidrs)
+}
+
+// Client is a Site Shield API client.
+type Client struct {
+	client *edgegrid.Client
+}
+
+// New creates a Site Shield client sending requests with client.
+func New(client *edgegrid.Client) *Client {
+	return &Client{client: client}
+}
+
+// Maps lists the Site Shield maps of the account.
+func (c *Client) Maps(ctx context.Context) ([]Map, error) {
+	res, _, err := edgegrid.GetJSON[MapsResponse](ctx, c.client, "/siteshield/v1/maps", nil)
+	return res.SiteShieldMaps, err
+}
+
+// Map returns the Site Shield map id.
+func (c *Client) Map(ctx context.Context, id int) (*Map, error) {
+	m, _, err := edgegrid.GetJSON[*Map](ctx, c.client, fmt.Sprintf("/siteshield/v1/maps/%d", id), nil)
+	return m, err
+}
+
+// Acknowledge acknowledges the proposed CIDRs of map id, once they are allowed by the origin firewall.
+func (c *Client) Acknowledge(ctx context.Context, id int) (*Map, error) {
+	m, _, err := edgegrid.SendJSON[*struct{}, *Map](ctx, c.client, "POST", fmt.Sprintf("/siteshield/v1/maps/%d/acknowledge", id), nil)
+	return m, err
+}
+
+// DiffCIDRs compares current and proposed CIDR blocks. Bare IP addresses are treated as single
+// address prefixes and all prefixes are normalized, so formatting differences are ignored.
+// The results are sorted.
+func DiffCIDRs(current, proposed []string) (CIDRDiff, error) {
+	currentSet, err := prefixSet(current)
+	if err != nil {
+		return CIDRDiff{}, err
+	}
+	proposedSet, err := prefixSet(proposed)
+	if err != nil {
+		return CIDRDiff{}, err
+	}
+
+	diff := CIDRDiff{}
+	for prefix := range proposedSet {
+		if currentSet[prefix] {
+			diff.Unchanged = append(diff.Unchanged, prefix)
+		} else {
+			diff.Added = append(diff.Added, prefix)
+		}
+	}
+	for prefix := range currentSet {
+		if !proposedSet[prefix] {
+			diff.Removed = append(diff.Removed, prefix)
+		}
+	}
+
+	sortPrefixes(diff.Added)
+	sortPrefixes(diff.Removed)
+	sortPrefixes(diff.Unchanged)
+	return diff, nil
+}
+
+func prefixSet(cidrs []string) (map[netip.Prefix]bool, error) {
+	set := make(map[netip.Prefix]bool, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := parsePrefix(cidr)
+		if err != nil {
+			return nil, err
+		}
+		set[prefix] = true
+	}
+	return set, nil
+}
+
+func parsePrefix(cidr string) (netip.Prefix, error) {
+	if prefix, err := netip.ParsePrefix(cidr); err == nil {
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(cidr)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("Invalid CIDR %q", cidr)
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+func sortPrefixes(prefixes []netip.Prefix) {
+	sort.Slice(prefixes, func(i, j int) bool {
+		if c := prefixes[i].Addr().Compare(prefixes[j].Addr()); c != 0 {
+			return c < 0
+		}
+		return prefixes[i].Bits() < prefixes[j].Bits()
+	})
+}
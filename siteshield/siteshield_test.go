@@ -0,0 +1,77 @@
+package siteshield
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/akamai-open/AkamaiOPEN-edgegrid-golang/edgegridtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var testMap = Map{
+	ID:            1,
+	RuleName:      "a;s.akamaiedge.net",
+	Type:          "Production",
+	CurrentCidrs:  []string{"192.0.2.0/24", "198.51.100.0/24", "203.0.113.7"},
+	ProposedCidrs: []string{"192.0.2.0/24", "203.0.113.7/32", "2001:db8::/32"},
+}
+
+func prefixes(cidrs ...string) []netip.Prefix {
+	var out []netip.Prefix
+	for _, cidr := range cidrs {
+		out = append(out, netip.MustParsePrefix(cidr))
+	}
+	return out
+}
+
+func TestMaps(t *testing.T) {
+	s := edgegridtest.NewServer()
+	defer s.Close()
+	s.HandleJSON("GET /siteshield/v1/maps", http.StatusOK, MapsResponse{SiteShieldMaps: []Map{testMap}})
+	s.HandleJSON("GET /siteshield/v1/maps/1", http.StatusOK, testMap)
+
+	acknowledged := testMap
+	acknowledged.Acknowledged = true
+	acknowledged.CurrentCidrs = acknowledged.ProposedCidrs
+	s.HandleJSON("POST /siteshield/v1/maps/1/acknowledge", http.StatusOK, acknowledged)
+
+	c := New(s.Client())
+	ctx := context.Background()
+
+	maps, err := c.Maps(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []Map{testMap}, maps)
+
+	m, err := c.Map(ctx, 1)
+	assert.NoError(t, err)
+	assert.False(t, m.Acknowledged)
+
+	m, err = c.Acknowledge(ctx, 1)
+	assert.NoError(t, err)
+	assert.True(t, m.Acknowledged)
+
+	diff, err := m.Diff()
+	assert.NoError(t, err)
+	assert.True(t, diff.Empty())
+
+	_, err = c.Map(ctx, 2)
+	assert.Error(t, err)
+}
+
+func TestDiffCIDRs(t *testing.T) {
+	diff, err := testMap.Diff()
+	assert.NoError(t, err)
+	assert.Equal(t, prefixes("2001:db8::/32"), diff.Added)
+	assert.Equal(t, prefixes("198.51.100.0/24"), diff.Removed)
+	assert.Equal(t, prefixes("192.0.2.0/24", "203.0.113.7/32"), diff.Unchanged)
+	assert.False(t, diff.Empty())
+
+	diff, err = DiffCIDRs([]string{"10.0.0.1/8"}, []string{"10.0.0.0/8"})
+	assert.NoError(t, err)
+	assert.True(t, diff.Empty())
+
+	_, err = DiffCIDRs([]string{"not-a-cidr"}, nil)
+	assert.Error(t, err)
+}